@@ -0,0 +1,9 @@
+data "kibana_cases" "incidents" {
+  space_id = "observability"
+  status   = "open"
+  tags     = ["incident"]
+}
+
+output "open_incidents" {
+  value = [for c in data.kibana_cases.incidents.cases : c.title]
+}
@@ -0,0 +1,8 @@
+data "kibana_fleet_enrollment_tokens" "example" {
+  policy_id = kibana_fleet_agent_policy.example.id
+}
+
+output "enrollment_token" {
+  value     = data.kibana_fleet_enrollment_tokens.example.tokens[0].token
+  sensitive = true
+}
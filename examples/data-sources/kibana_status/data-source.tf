@@ -0,0 +1,12 @@
+data "kibana_status" "current" {
+  wait_for_available = true
+  wait_timeout       = "10m"
+}
+
+output "kibana_version" {
+  value = data.kibana_status.current.version
+}
+
+output "degraded_plugins" {
+  value = [for p in data.kibana_status.current.plugins : p.name if p.level != "available"]
+}
@@ -0,0 +1,6 @@
+#! /bin/bash
+
+# Advanced settings are imported by space identifier, use default for the
+# default space and global for the global settings. Every setting which doesn't
+# use its default value is imported.
+terraform import kibana_advanced_settings.example observability
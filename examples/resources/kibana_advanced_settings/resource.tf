@@ -0,0 +1,25 @@
+resource "kibana_advanced_settings" "example" {
+  space_id = "observability"
+  settings = {
+    "defaultIndex"   = kibana_data_view.example.id
+    "theme:darkMode" = "true"
+    "dateFormat"     = "YYYY-MM-DD HH:mm:ss"
+    "timepicker:quickRanges" = jsonencode([
+      {
+        from    = "now-15m"
+        to      = "now"
+        display = "Last 15 minutes"
+      },
+    ])
+  }
+  json_settings = {
+    "defaultColumns" = jsonencode(["message", "service.name"])
+  }
+}
+
+resource "kibana_advanced_settings" "global" {
+  global = true
+  settings = {
+    "notifications:banner" = "Maintenance scheduled on Saturday"
+  }
+}
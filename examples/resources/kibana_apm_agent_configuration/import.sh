@@ -0,0 +1,5 @@
+#! /bin/bash
+
+# APM agent configurations are imported as <service_name>:<environment>, or
+# <service_name> when they apply to all environments
+terraform import kibana_apm_agent_configuration.example checkout:production
@@ -0,0 +1,11 @@
+resource "kibana_apm_agent_configuration" "checkout" {
+  service_name = "checkout"
+  environment  = "production"
+  agent_name   = "java"
+
+  settings = {
+    transaction_sample_rate = "0.2"
+    capture_body            = "errors"
+    log_level               = "warn"
+  }
+}
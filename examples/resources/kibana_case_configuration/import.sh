@@ -0,0 +1,5 @@
+#! /bin/bash
+
+# Case configurations living outside of the default space are imported as
+# <space_id>/<configuration_id>
+terraform import kibana_case_configuration.example observability/4a3e2b10-7c1d-11ee-9f2e-4b8a2c6d1e0f
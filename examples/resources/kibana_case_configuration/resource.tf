@@ -0,0 +1,29 @@
+resource "kibana_case_configuration" "observability" {
+  space_id     = "observability"
+  owner        = "observability"
+  closure_type = "close-by-pushing"
+
+  connector {
+    id   = "9a6d2e1c-3b4f-4e5a-8c7d-1f2e3d4c5b6a"
+    name = "Jira"
+    type = ".jira"
+  }
+
+  custom_field {
+    key      = "customer_impact"
+    label    = "Customer impact"
+    type     = "toggle"
+    required = true
+  }
+
+  template {
+    key         = "incident"
+    name        = "Incident"
+    description = "Production incident"
+    tags        = ["incident"]
+    case_fields = jsonencode({
+      severity = "high"
+      tags     = ["incident"]
+    })
+  }
+}
@@ -0,0 +1,5 @@
+#! /bin/bash
+
+# Data views living outside of the default space are imported as
+# <space_id>/<data_view_id>
+terraform import kibana_data_view.example observability/ff959d40-b880-11e8-a6d9-e546fe2bba5f
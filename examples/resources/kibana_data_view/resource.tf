@@ -0,0 +1,28 @@
+resource "kibana_data_view" "example" {
+  space_id        = "observability"
+  title           = "logs-*"
+  name            = "Logs"
+  time_field_name = "@timestamp"
+  source_filters  = ["secret.*"]
+  field_formats = jsonencode(
+    {
+      "event.duration" = {
+        id = "duration"
+        params = {
+          inputFormat  = "nanoseconds"
+          outputFormat = "asMilliseconds"
+        }
+      }
+    }
+  )
+  runtime_field_map = jsonencode(
+    {
+      day_of_week = {
+        type = "keyword"
+        script = {
+          source = "emit(doc['@timestamp'].value.dayOfWeekEnum.getDisplayName(TextStyle.FULL, Locale.ROOT))"
+        }
+      }
+    }
+  )
+}
@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Agent policies are imported by ID
+terraform import kibana_fleet_agent_policy.example 2b820230-4b54-11ed-b3b6-2f9c7b5ae4e4
@@ -0,0 +1,8 @@
+resource "kibana_fleet_agent_policy" "example" {
+  name               = "Linux servers"
+  namespace          = "production"
+  description        = "Agents running on our Linux servers"
+  monitoring_enabled = ["logs", "metrics"]
+  inactivity_timeout = 1209600
+  is_protected       = true
+}
@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Enrollment tokens are imported by ID, their name is derived from the name in Kibana
+terraform import kibana_fleet_enrollment_token.example 39d5e7e0-4b55-11ed-b3b6-2f9c7b5ae4e4
@@ -0,0 +1,9 @@
+resource "kibana_fleet_enrollment_token" "example" {
+  name      = "cloud-init"
+  policy_id = kibana_fleet_agent_policy.example.id
+}
+
+output "enroll_command" {
+  value     = "elastic-agent enroll --url=https://fleet.example.com:8220 --enrollment-token=${kibana_fleet_enrollment_token.example.token}"
+  sensitive = true
+}
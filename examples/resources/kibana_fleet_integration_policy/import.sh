@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Integration policies are imported by ID
+terraform import kibana_fleet_integration_policy.example 8e5ba7c0-4b59-11ed-b3b6-2f9c7b5ae4e4
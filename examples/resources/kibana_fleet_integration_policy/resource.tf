@@ -0,0 +1,20 @@
+resource "kibana_fleet_integration_policy" "example" {
+  name                = "nginx-production"
+  agent_policy_id     = kibana_fleet_agent_policy.example.id
+  integration_name    = "nginx"
+  integration_version = "1.13.0"
+  input {
+    input_id = "nginx-logfile"
+    stream {
+      stream_id = "nginx.access"
+      vars      = jsonencode({ paths = ["/var/log/nginx/access.log*"] })
+    }
+  }
+  input {
+    input_id = "nginx-nginx/metrics"
+    vars     = jsonencode({ hosts = ["http://127.0.0.1:80"] })
+    secret_vars = jsonencode({
+      password = var.nginx_status_password
+    })
+  }
+}
@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Outputs are imported by ID
+terraform import kibana_fleet_output.example fleet-default-output
@@ -0,0 +1,26 @@
+resource "kibana_fleet_output" "logstash" {
+  name     = "Logstash"
+  type     = "logstash"
+  hosts    = ["logstash.example.com:5044"]
+  proxy_id = kibana_fleet_proxy.example.id
+
+  ssl {
+    certificate_authorities = [file("ca.crt")]
+    certificate             = file("client.crt")
+    key                     = file("client.key")
+  }
+}
+
+resource "kibana_fleet_output" "kafka" {
+  name  = "Kafka"
+  type  = "kafka"
+  hosts = ["kafka-1.example.com:9092", "kafka-2.example.com:9092"]
+
+  kafka {
+    auth_type   = "user_pass"
+    username    = "fleet"
+    password    = var.kafka_password
+    topic       = "elastic-agent"
+    compression = "gzip"
+  }
+}
@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Packages are imported by name, the installed version is read from Kibana
+terraform import kibana_fleet_package.nginx nginx
@@ -0,0 +1,11 @@
+resource "kibana_fleet_package" "nginx" {
+  name    = "nginx"
+  version = "1.13.0"
+}
+
+// Custom integration built with elastic-package
+resource "kibana_fleet_package" "custom" {
+  name         = "acme_billing"
+  version      = "0.3.1"
+  archive_path = "${path.module}/packages/acme_billing-0.3.1.zip"
+}
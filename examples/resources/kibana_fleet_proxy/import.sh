@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Proxies are imported by ID
+terraform import kibana_fleet_proxy.example 0e0f14e7-6c74-4d8e-a8d5-cf3a4b1a5a43
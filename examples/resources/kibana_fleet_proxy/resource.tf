@@ -0,0 +1,8 @@
+resource "kibana_fleet_proxy" "example" {
+  name = "Corporate proxy"
+  url  = "http://proxy.example.com:3128"
+
+  proxy_headers = {
+    Proxy-Authorization = "Basic ${base64encode("fleet:${var.proxy_password}")}"
+  }
+}
@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Fleet Server hosts are imported by ID
+terraform import kibana_fleet_server_host.example fleet-default-fleet-server-host
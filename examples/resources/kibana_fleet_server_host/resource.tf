@@ -0,0 +1,6 @@
+resource "kibana_fleet_server_host" "example" {
+  name       = "Fleet Server"
+  host_urls  = ["https://fleet.example.com:8220"]
+  is_default = true
+  proxy_id   = kibana_fleet_proxy.example.id
+}
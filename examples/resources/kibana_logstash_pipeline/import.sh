@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Pipelines are imported by pipeline ID
+terraform import kibana_logstash_pipeline.example beats
@@ -0,0 +1,12 @@
+resource "kibana_logstash_pipeline" "beats" {
+  pipeline_id = "beats"
+  description = "Beats events to Elasticsearch"
+  pipeline    = file("${path.module}/pipelines/beats.conf")
+
+  settings {
+    workers         = 4
+    batch_size      = 250
+    queue_type      = "persisted"
+    queue_max_bytes = "4gb"
+  }
+}
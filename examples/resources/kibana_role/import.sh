@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Roles are imported by name
+terraform import kibana_role.example observability_reader
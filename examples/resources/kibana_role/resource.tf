@@ -0,0 +1,31 @@
+resource "kibana_role" "example" {
+  name     = "observability_reader"
+  metadata = jsonencode({ team = "sre" })
+  elasticsearch {
+    cluster = ["monitor"]
+    indices {
+      names      = ["logs-*", "metrics-*"]
+      privileges = ["read", "view_index_metadata"]
+      query      = jsonencode({ term = { "service.environment" = "production" } })
+      field_security {
+        grant  = ["*"]
+        except = ["user.email"]
+      }
+    }
+  }
+  kibana {
+    spaces = ["observability"]
+    feature {
+      name       = "discover"
+      privileges = ["read"]
+    }
+    feature {
+      name       = "dashboard"
+      privileges = ["read"]
+    }
+  }
+  kibana {
+    spaces = ["default"]
+    base   = ["read"]
+  }
+}
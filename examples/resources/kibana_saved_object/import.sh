@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Saved objects are imported as [<space_id>/]<type>/<object_id>
+terraform import kibana_saved_object.example observability/search/errors-search
@@ -0,0 +1,25 @@
+resource "kibana_saved_object" "example" {
+  space_id  = "observability"
+  type      = "search"
+  object_id = "errors-search"
+  attributes = jsonencode(
+    {
+      title   = "Errors"
+      columns = ["message", "service.name"]
+      sort    = [["@timestamp", "desc"]]
+      kibanaSavedObjectMeta = {
+        searchSourceJSON = jsonencode(
+          {
+            query        = { query = "log.level:error", language = "kuery" }
+            indexRefName = "kibanaSavedObjectMeta.searchSourceJSON.index"
+          }
+        )
+      }
+    }
+  )
+  references {
+    type = "index-pattern"
+    id   = kibana_data_view.example.id
+    name = "kibanaSavedObjectMeta.searchSourceJSON.index"
+  }
+}
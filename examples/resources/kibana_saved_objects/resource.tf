@@ -0,0 +1,5 @@
+resource "kibana_saved_objects" "example" {
+  space_id    = "observability"
+  ndjson_file = "${path.module}/dashboards/overview.ndjson"
+  overwrite   = true
+}
@@ -0,0 +1,5 @@
+#! /bin/bash
+
+# SLOs living outside of the default space are imported as
+# <space_id>/<slo_id>
+terraform import kibana_slo.example observability/checkout-availability
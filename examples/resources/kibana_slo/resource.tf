@@ -0,0 +1,51 @@
+resource "kibana_slo" "checkout_availability" {
+  name        = "Checkout availability"
+  description = "99.5% of the checkout requests succeed"
+
+  kql_custom_indicator {
+    index  = "logs-nginx.access-*"
+    filter = "url.path : \"/checkout\""
+    good   = "http.response.status_code < 500"
+    total  = "http.response.status_code : *"
+  }
+
+  time_window {
+    duration = "30d"
+    type     = "rolling"
+  }
+
+  budgeting_method = "occurrences"
+
+  objective {
+    target = 0.995
+  }
+
+  group_by = ["host.name"]
+  tags     = ["checkout"]
+}
+
+resource "kibana_slo" "api_latency" {
+  name = "API latency"
+
+  apm_latency_indicator {
+    service          = "api"
+    environment      = "production"
+    transaction_type = "request"
+    transaction_name = "*"
+    index            = "metrics-apm*,apm-*"
+    threshold        = 500
+  }
+
+  time_window {
+    duration = "1M"
+    type     = "calendarAligned"
+  }
+
+  budgeting_method = "timeslices"
+
+  objective {
+    target           = 0.99
+    timeslice_target = 0.95
+    timeslice_window = "5m"
+  }
+}
@@ -0,0 +1,5 @@
+#! /bin/bash
+
+# Monitors living outside of the default space are imported as
+# <space_id>/<monitor_id>
+terraform import kibana_synthetics_monitor.example observability/0a2c7d9e-5f4b-4f1e-9b0e-3c8d4f2a6b1c
@@ -0,0 +1,27 @@
+resource "kibana_synthetics_monitor" "website" {
+  name              = "Website"
+  schedule          = 5
+  locations         = ["us_central"]
+  private_locations = [kibana_synthetics_private_location.example.id]
+  tags              = ["frontend"]
+
+  http {
+    url           = "https://www.example.com"
+    max_redirects = 3
+  }
+}
+
+resource "kibana_synthetics_monitor" "login" {
+  name      = "Login journey"
+  schedule  = 10
+  locations = ["us_central"]
+  params    = jsonencode({ username = "synthetics" })
+
+  browser {
+    inline_script = <<-EOT
+      step('Open the login page', async () => {
+        await page.goto('https://www.example.com/login');
+      });
+    EOT
+  }
+}
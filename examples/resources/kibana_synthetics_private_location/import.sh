@@ -0,0 +1,4 @@
+#! /bin/bash
+
+# Private locations are imported by ID
+terraform import kibana_synthetics_private_location.example 8b9c3f1a-2d4e-4c5f-a6b7-1e2d3c4b5a69
@@ -0,0 +1,15 @@
+resource "kibana_fleet_agent_policy" "synthetics" {
+  name      = "Synthetics Paris"
+  namespace = "default"
+}
+
+resource "kibana_synthetics_private_location" "example" {
+  label           = "Paris"
+  agent_policy_id = kibana_fleet_agent_policy.synthetics.id
+  tags            = ["europe"]
+
+  geo {
+    lat = 48.86
+    lon = 2.35
+  }
+}
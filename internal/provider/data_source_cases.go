@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func dataSourceCases() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the cases of a space, filtered by status, owner and tags.",
+
+		ReadContext: dataSourceCasesRead,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description: "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description:  "Only lists the cases with this status: open, in-progress or closed. An empty status lists every case.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "open",
+				ValidateFunc: validation.StringInSlice([]string{"", "open", "in-progress", "closed"}, false),
+			},
+			"owner": {
+				Description: "Only lists the cases of this owner: cases, observability or securitySolution.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": {
+				Description: "Only lists the cases having at least one of these tags.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"cases": {
+				Description: "The cases.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"title": {
+							Description: "The title of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The status of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"severity": {
+							Description: "The severity of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tags": {
+							Description: "The tags of the case.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"owner": {
+							Description: "The owner of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "The creation date of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_by": {
+							Description: "The username of the creator of the case.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"total_comments": {
+							Description: "The number of comments of the case.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"external_url": {
+							Description: "The URL of the case in the system it was pushed to.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	filter := mykibana.CaseFilter{
+		Status: d.Get("status").(string),
+		Owner:  d.Get("owner").(string),
+		Tags:   deflateStringList(d.Get("tags").([]interface{})),
+	}
+	cases, err := client.FindCases(d.Get("space_id").(string), filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res := make([]map[string]interface{}, 0, len(cases))
+	for _, c := range cases {
+		flatCase := make(map[string]interface{})
+		flatCase["id"] = c.Id
+		flatCase["title"] = c.Title
+		flatCase["description"] = c.Description
+		flatCase["status"] = c.Status
+		flatCase["severity"] = c.Severity
+		flatCase["tags"] = c.Tags
+		flatCase["owner"] = c.Owner
+		flatCase["created_at"] = c.CreatedAt
+		flatCase["created_by"] = c.CreatedBy.Username
+		flatCase["total_comments"] = c.TotalComment
+		if c.ExternalService != nil {
+			flatCase["external_url"] = c.ExternalService.ExternalUrl
+		}
+		res = append(res, flatCase)
+	}
+	d.Set("cases", res)
+	d.SetId(strings.Join([]string{d.Get("space_id").(string), filter.Status, filter.Owner, strings.Join(filter.Tags, ",")}, "/"))
+
+	return diags
+}
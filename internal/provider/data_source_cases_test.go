@@ -0,0 +1,31 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestKibanaCasesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getCasesDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kibana_cases.test", "cases.#"),
+					resource.TestCheckResourceAttr("data.kibana_cases.test", "status", "open"),
+				),
+			},
+		},
+	})
+}
+
+func getCasesDataSourceConfig() string {
+	return `
+	data "kibana_cases" "test" {
+    owner = "cases"
+    tags  = ["incident"]
+    }
+	`
+}
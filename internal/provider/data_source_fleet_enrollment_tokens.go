@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func dataSourceFleetEnrollmentTokens() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the Fleet enrollment tokens, optionally filtered by agent policy.",
+
+		ReadContext: dataSourceFleetEnrollmentTokensRead,
+
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Description: "Only lists the tokens of this agent policy.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"active_only": {
+				Description: "Skips the revoked tokens.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"tokens": {
+				Description: "The enrollment tokens.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the token.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the token.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"policy_id": {
+							Description: "The ID of the agent policy of the token.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"token": {
+							Description: "The enrollment token, passed to elastic-agent enroll.",
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"api_key_id": {
+							Description: "The ID of the Elasticsearch API key backing the token.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"active": {
+							Description: "Whether the token can enroll agents.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "The creation date of the token.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFleetEnrollmentTokensRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	policyId := d.Get("policy_id").(string)
+	tokens, err := client.ListFleetEnrollmentTokens(policyId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	activeOnly := d.Get("active_only").(bool)
+	res := make([]map[string]interface{}, 0, len(tokens))
+	for _, t := range tokens {
+		if activeOnly && !t.Active {
+			continue
+		}
+		token := make(map[string]interface{})
+		token["id"] = t.Id
+		token["name"] = t.Name
+		token["policy_id"] = t.PolicyId
+		token["token"] = t.ApiKey
+		token["api_key_id"] = t.ApiKeyId
+		token["active"] = t.Active
+		token["created_at"] = t.CreatedAt
+		res = append(res, token)
+	}
+	d.Set("tokens", res)
+	if policyId == "" {
+		d.SetId("all")
+	} else {
+		d.SetId(policyId)
+	}
+
+	return diags
+}
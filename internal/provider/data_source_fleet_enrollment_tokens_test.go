@@ -0,0 +1,40 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestKibanaFleetEnrollmentTokensDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getFleetEnrollmentTokensDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_fleet_enrollment_tokens.test", "tokens.#", "1"),
+					resource.TestCheckResourceAttrPair("data.kibana_fleet_enrollment_tokens.test", "tokens.0.id", "kibana_fleet_enrollment_token.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func getFleetEnrollmentTokensDataSourceConfig() string {
+	return `
+	resource "kibana_fleet_agent_policy" "test" {
+    name      = "Enrollment tokens data source"
+    namespace = "default"
+    }
+
+	resource "kibana_fleet_enrollment_token" "test" {
+    name      = "bootstrap"
+    policy_id = kibana_fleet_agent_policy.test.id
+    }
+
+	data "kibana_fleet_enrollment_tokens" "test" {
+    policy_id = kibana_fleet_enrollment_token.test.policy_id
+    }
+	`
+}
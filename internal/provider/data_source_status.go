@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func dataSourceStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the version and the health of Kibana, optionally waiting until Kibana is available.",
+
+		ReadContext: dataSourceStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"wait_for_available": {
+				Description: "Waits until the overall status of Kibana is available.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"wait_timeout": {
+				Description: "How long to wait for Kibana to be available, for example 10m.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "5m",
+				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+					if _, err := time.ParseDuration(i.(string)); err != nil {
+						return nil, []error{fmt.Errorf("%s is not a valid duration: %s", k, err)}
+					}
+					return nil, nil
+				},
+			},
+			"name": {
+				Description: "The name of the Kibana instance.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"uuid": {
+				Description: "The UUID of the Kibana instance.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"version": {
+				Description: "The version number of Kibana, for example 8.6.2.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"build_hash": {
+				Description: "The commit hash of the Kibana build.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"build_number": {
+				Description: "The number of the Kibana build.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"build_snapshot": {
+				Description: "Whether Kibana is a snapshot build.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"overall_level": {
+				Description: "The overall status level: available, degraded, unavailable or critical.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"overall_summary": {
+				Description: "The summary of the overall status.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"plugins": {
+				Description: "The status of the plugins.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The plugin name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"level": {
+							Description: "The status level of the plugin.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"summary": {
+							Description: "The summary of the plugin status.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	var status mykibana.KibanaStatus
+	var err error
+	if d.Get("wait_for_available").(bool) {
+		timeout, _ := time.ParseDuration(d.Get("wait_timeout").(string))
+		err = resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+			status, err = client.ReadStatus()
+			if err != nil {
+				return resource.RetryableError(err)
+			}
+			if status.Status.Overall.Level != mykibana.KibanaStatusAvailable {
+				return resource.RetryableError(fmt.Errorf("Kibana is %s: %s", status.Status.Overall.Level, status.Status.Overall.Summary))
+			}
+			return nil
+		})
+	} else {
+		status, err = client.ReadStatus()
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(status.Uuid)
+	if status.Uuid == "" {
+		d.SetId(status.Name)
+	}
+	d.Set("name", status.Name)
+	d.Set("uuid", status.Uuid)
+	d.Set("version", status.Version.Number)
+	d.Set("build_hash", status.Version.BuildHash)
+	d.Set("build_number", status.Version.BuildNumber)
+	d.Set("build_snapshot", status.Version.BuildSnapshot)
+	d.Set("overall_level", status.Status.Overall.Level)
+	d.Set("overall_summary", status.Status.Overall.Summary)
+	d.Set("plugins", flattenStatusPlugins(status.Status.Plugins))
+
+	return diags
+}
+
+func flattenStatusPlugins(plugins map[string]mykibana.KibanaServiceStatus) []map[string]interface{} {
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := make([]map[string]interface{}, 0, len(plugins))
+	for _, name := range names {
+		res = append(res, map[string]interface{}{
+			"name":    name,
+			"level":   plugins[name].Level,
+			"summary": plugins[name].Summary,
+		})
+	}
+	return res
+}
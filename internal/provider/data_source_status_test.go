@@ -0,0 +1,31 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestKibanaStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getStatusDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kibana_status.test", "overall_level", "available"),
+					resource.TestCheckResourceAttrSet("data.kibana_status.test", "plugins.#"),
+				),
+			},
+		},
+	})
+}
+
+func getStatusDataSourceConfig() string {
+	return `
+	data "kibana_status" "test" {
+    wait_for_available = true
+    wait_timeout       = "1m"
+    }
+	`
+}
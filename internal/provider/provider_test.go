@@ -1,12 +1,18 @@
 package provider_test
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	myprovider "github.com/qonto/terraform-provider-kibana/internal/provider"
 	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+	"github.com/qonto/terraform-provider-kibana/pkg/kibanafake"
 )
 
 var provider *schema.Provider
@@ -19,6 +25,36 @@ func init() {
 		"kibana": provider,
 	}
 }
+
+// fakeProviders returns providers running the real Kibana client against an
+// in-memory Kibana, which the configuration addresses with
+// fakeProviderConfig. The tests using them run with resource.UnitTest, so
+// without TF_ACC, but still drive a Terraform CLI: the one of
+// TF_ACC_TERRAFORM_PATH or TF_ACC_TERRAFORM_VERSION, or else the one on PATH.
+// They are skipped when none is available, rather than letting the SDK
+// download the latest Terraform.
+func fakeProviders(t *testing.T) (map[string]*schema.Provider, *kibanafake.Server) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" && os.Getenv("TF_ACC_TERRAFORM_VERSION") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("Requires a Terraform CLI on PATH, in TF_ACC_TERRAFORM_PATH or TF_ACC_TERRAFORM_VERSION")
+		}
+	}
+	fake := kibanafake.NewServer()
+	t.Cleanup(fake.Close)
+	return map[string]*schema.Provider{
+		"kibana": myprovider.New(mykibana.CreateNewKibanaClient())(),
+	}, fake
+}
+
+func fakeProviderConfig(fake *kibanafake.Server) string {
+	return fmt.Sprintf(`
+	provider "kibana" {
+		kibana_host = "%s"
+		kibana_auth = "ZWxhc3RpYzpjaGFuZ2VtZQ=="
+	}
+	`, fake.URL)
+}
+
 func TestProvider(t *testing.T) {
 	k := mykibana.KibanaMockClient{}
 	if err := myprovider.New(&k)().InternalValidate(); err != nil {
@@ -30,3 +66,87 @@ func TestProvider_impl(t *testing.T) {
 	k := mykibana.KibanaMockClient{}
 	var _ *schema.Provider = myprovider.New(&k)()
 }
+
+func TestProvider_addressingModes(t *testing.T) {
+	k := mykibana.KibanaMockClient{}
+	p := myprovider.New(&k)()
+	for _, tc := range []struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{"kibana_auth": "auth", "kibana_host": "https://kibana:5601"}, true},
+		{map[string]interface{}{"kibana_auth": "auth", "cloud_id": "deployment:ZXUtd2VzdC0xLmF3cy5mb3VuZC5pbyRlcyRrYg=="}, true},
+		{map[string]interface{}{"kibana_auth": "auth", "endpoints": []interface{}{"https://kibana-1:5601", "https://kibana-2:5601"}}, true},
+		{map[string]interface{}{"kibana_auth": "auth", "kibana_host": "https://kibana:5601", "endpoints": []interface{}{"https://kibana-1:5601"}}, false},
+		{map[string]interface{}{"kibana_auth": "auth", "kibana_host": "https://kibana:5601", "cloud_id": "deployment:ZXUtd2VzdC0xLmF3cy5mb3VuZC5pbyRlcyRrYg=="}, false},
+	} {
+		diags := p.Validate(terraform.NewResourceConfigRaw(tc.config))
+		if diags.HasError() == tc.valid {
+			t.Errorf("%v: unexpected validation result %v", tc.config, diags)
+		}
+	}
+}
+
+func TestProvider_addressingModesFromEnvironment(t *testing.T) {
+	cloudId := "deployment:ZXUtd2VzdC0xLmF3cy5mb3VuZC5pbyRlcyRrYg=="
+	cloudUrl, err := mykibana.KibanaUrlFromCloudId(cloudId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		env    map[string]string
+		config map[string]interface{}
+		host   string
+	}{
+		{map[string]string{"KIBANA_HOST": "https://env:5601"}, map[string]interface{}{}, "https://env:5601"},
+		{map[string]string{"KIBANA_CLOUD_ID": cloudId}, map[string]interface{}{}, cloudUrl},
+		{map[string]string{"KIBANA_HOST": "https://env:5601"}, map[string]interface{}{"kibana_host": "https://kibana:5601"}, "https://kibana:5601"},
+		{map[string]string{"KIBANA_HOST": "https://env:5601"}, map[string]interface{}{"cloud_id": cloudId}, cloudUrl},
+		{map[string]string{"KIBANA_HOST": "https://env:5601"}, map[string]interface{}{"endpoints": []interface{}{"https://kibana-1:5601", "https://kibana-2:5601"}}, "https://kibana-1:5601"},
+		{map[string]string{"KIBANA_CLOUD_ID": cloudId}, map[string]interface{}{"kibana_host": "https://kibana:5601"}, "https://kibana:5601"},
+		{map[string]string{}, map[string]interface{}{}, ""},
+		{map[string]string{"KIBANA_HOST": "https://env:5601", "KIBANA_CLOUD_ID": cloudId}, map[string]interface{}{}, ""},
+	} {
+		t.Run(fmt.Sprintf("%v %v", tc.env, tc.config), func(t *testing.T) {
+			t.Setenv("KIBANA_HOST", tc.env["KIBANA_HOST"])
+			t.Setenv("KIBANA_CLOUD_ID", tc.env["KIBANA_CLOUD_ID"])
+			k := mykibana.KibanaMockClient{}
+			p := myprovider.New(&k)()
+			tc.config["kibana_auth"] = "auth"
+			config := terraform.NewResourceConfigRaw(tc.config)
+			if diags := p.Validate(config); diags.HasError() {
+				t.Fatalf("unexpected validation error: %v", diags)
+			}
+			diags := p.Configure(context.Background(), config)
+			if tc.host == "" {
+				if !diags.HasError() {
+					t.Errorf("expected a configuration error, Kibana is addressed with %s", k.KibanaHost)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected configuration error: %v", diags)
+			}
+			if k.KibanaHost != tc.host {
+				t.Errorf("Kibana addressed with %s, expected %s", k.KibanaHost, tc.host)
+			}
+		})
+	}
+}
+
+func TestProvider_reservedHeaders(t *testing.T) {
+	k := mykibana.KibanaMockClient{}
+	p := myprovider.New(&k)()
+	config := map[string]interface{}{
+		"kibana_auth": "auth",
+		"kibana_host": "https://kibana:5601",
+		"headers":     map[string]interface{}{"X-Route": "blue"},
+	}
+	if diags := p.Validate(terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	config["headers"] = map[string]interface{}{"kbn-xsrf": "true"}
+	if diags := p.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Fatal("kbn-xsrf should not be overridable")
+	}
+}
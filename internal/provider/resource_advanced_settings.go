@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceAdvancedSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages Kibana advanced settings (uiSettings) of a space, or the global settings. Only the declared settings are managed, the other ones are left untouched.",
+
+		CreateContext: resourceAdvancedSettingsCreate,
+		ReadContext:   resourceAdvancedSettingsRead,
+		UpdateContext: resourceAdvancedSettingsUpdate,
+		DeleteContext: resourceAdvancedSettingsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description:   "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"global"},
+			},
+			"global": {
+				Description:   "Manages the global settings shared by every space instead of the settings of a space.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"space_id"},
+			},
+			"settings": {
+				Description:      "The settings to manage, keyed by setting name. true, false and numbers are sent as booleans and numbers, other values as strings.",
+				Type:             schema.TypeMap,
+				Optional:         true,
+				DiffSuppressFunc: settingValueEqual,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"json_settings": {
+				Description:      "The settings to manage whose value is sent as raw JSON, such as arrays, keyed by setting name.",
+				Type:             schema.TypeMap,
+				Optional:         true,
+				DiffSuppressFunc: settingValueEqual,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAdvancedSettingsImport,
+		},
+	}
+}
+
+func resourceAdvancedSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	changes, err := deflateAdvancedSettings(d.Get("settings").(map[string]interface{}), d.Get("json_settings").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = client.UpdateAdvancedSettings(d.Get("space_id").(string), d.Get("global").(bool), changes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(advancedSettingsId(d.Get("space_id").(string), d.Get("global").(bool)))
+	return resourceAdvancedSettingsRead(ctx, d, meta)
+}
+
+func resourceAdvancedSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	userValues, err := client.ReadAdvancedSettings(d.Get("space_id").(string), d.Get("global").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	settings := make(map[string]string)
+	for key := range d.Get("settings").(map[string]interface{}) {
+		if value, ok := userValues[key]; ok {
+			settings[key] = flattenSettingValue(value)
+		}
+	}
+	jsonSettings := make(map[string]string)
+	for key := range d.Get("json_settings").(map[string]interface{}) {
+		if value, ok := userValues[key]; ok {
+			jsonSettings[key] = string(value)
+		}
+	}
+	d.Set("settings", settings)
+	d.Set("json_settings", jsonSettings)
+
+	return diags
+}
+
+func resourceAdvancedSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	changes, err := deflateAdvancedSettings(d.Get("settings").(map[string]interface{}), d.Get("json_settings").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Settings removed from the configuration are reset to their default.
+	oldSettings, _ := d.GetChange("settings")
+	oldJsonSettings, _ := d.GetChange("json_settings")
+	for _, old := range []interface{}{oldSettings, oldJsonSettings} {
+		for key := range old.(map[string]interface{}) {
+			if _, ok := changes[key]; !ok {
+				changes[key] = json.RawMessage("null")
+			}
+		}
+	}
+	err = client.UpdateAdvancedSettings(d.Get("space_id").(string), d.Get("global").(bool), changes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceAdvancedSettingsRead(ctx, d, meta)
+}
+
+func resourceAdvancedSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	changes := make(map[string]json.RawMessage)
+	for _, key := range []string{"settings", "json_settings"} {
+		for name := range d.Get(key).(map[string]interface{}) {
+			changes[name] = json.RawMessage("null")
+		}
+	}
+	err := client.UpdateAdvancedSettings(d.Get("space_id").(string), d.Get("global").(bool), changes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourceAdvancedSettingsImport takes a space identifier, or global, and
+// imports every setting that doesn't use its default value.
+func resourceAdvancedSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(mykibana.KibanaAPI)
+	if d.Id() == "global" {
+		d.Set("global", true)
+	} else if d.Id() != "default" {
+		d.Set("space_id", d.Id())
+	}
+	userValues, err := client.ReadAdvancedSettings(d.Get("space_id").(string), d.Get("global").(bool))
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]string)
+	jsonSettings := make(map[string]string)
+	for key, value := range userValues {
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return nil, err
+		}
+		switch decoded.(type) {
+		case []interface{}, map[string]interface{}:
+			jsonSettings[key] = string(value)
+		default:
+			settings[key] = flattenSettingValue(value)
+		}
+	}
+	d.Set("settings", settings)
+	d.Set("json_settings", jsonSettings)
+	return []*schema.ResourceData{d}, nil
+}
+
+func advancedSettingsId(spaceId string, global bool) string {
+	if global {
+		return "global"
+	}
+	if spaceId == "" {
+		return "default"
+	}
+	return spaceId
+}
+
+func deflateAdvancedSettings(settings map[string]interface{}, jsonSettings map[string]interface{}) (map[string]json.RawMessage, error) {
+	changes := make(map[string]json.RawMessage)
+	for key, value := range settings {
+		changes[key] = deflateSettingValue(value.(string))
+	}
+	for key, value := range jsonSettings {
+		if !json.Valid([]byte(value.(string))) {
+			return nil, fmt.Errorf("Invalid JSON value for setting %s", key)
+		}
+		changes[key] = json.RawMessage(value.(string))
+	}
+	return changes, nil
+}
+
+func deflateSettingValue(value string) json.RawMessage {
+	if value == "true" || value == "false" {
+		return json.RawMessage(value)
+	}
+	// Numbers such as 007, +1, .5, NaN or 0x1p4 are parsed by ParseFloat but
+	// aren't valid JSON, they are sent as strings.
+	if _, err := strconv.ParseFloat(value, 64); err == nil && json.Valid([]byte(value)) {
+		return json.RawMessage(value)
+	}
+	jsonValue, _ := json.Marshal(value)
+	return jsonValue
+}
+
+func flattenSettingValue(value json.RawMessage) string {
+	var stringValue string
+	if err := json.Unmarshal(value, &stringValue); err == nil {
+		return stringValue
+	}
+	return string(value)
+}
+
+// settingValueEqual ignores formatting differences in settings holding JSON,
+// such as timepicker:quickRanges.
+func settingValueEqual(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if oldValue == newValue {
+		return true
+	}
+	var oldInterface, newInterface interface{}
+	if err := json.Unmarshal([]byte(oldValue), &oldInterface); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(newValue), &newInterface); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldInterface, newInterface)
+}
@@ -0,0 +1,70 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaAdvancedSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getAdvancedSettingsConfig("true"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAdvancedSettingsExist("kibana_advanced_settings.test"),
+					resource.TestCheckResourceAttr("kibana_advanced_settings.test", "id", "observability"),
+					resource.TestCheckResourceAttr("kibana_advanced_settings.test", "settings.theme:darkMode", "true"),
+					resource.TestCheckResourceAttr("kibana_advanced_settings.test", "json_settings.%", "1"),
+				),
+			},
+			{
+				Config: getAdvancedSettingsConfig("false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_advanced_settings.test", "settings.theme:darkMode", "false"),
+				),
+			},
+		},
+	})
+}
+
+func getAdvancedSettingsConfig(darkMode string) string {
+	return fmt.Sprintf(`
+	resource "kibana_advanced_settings" "test" {
+    space_id = "observability"
+    settings = {
+        "theme:darkMode"         = "%s"
+        "dateFormat"             = "YYYY-MM-DD HH:mm:ss"
+        "timepicker:quickRanges" = jsonencode([
+            {
+                from    = "now-15m"
+                to      = "now"
+                display = "Last 15 minutes"
+            },
+        ])
+    }
+    json_settings = {
+        "defaultColumns" = jsonencode(["message", "service.name"])
+    }
+    }
+	`, darkMode)
+}
+
+func testCheckAdvancedSettingsExist(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No advanced settings ID set")
+		}
+
+		return nil
+	}
+}
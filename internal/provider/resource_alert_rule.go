@@ -103,6 +103,31 @@ func resourceAlertRule() *schema.Resource {
 							Required:         true,
 							DiffSuppressFunc: rawJsonEqual,
 						},
+						"frequency": {
+							Description: "When the action runs, overriding notify_when and throttle of the rule. Requires Kibana 8.6 or later.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"summary": {
+										Description: "Sends a summary of the alerts instead of one notification per alert.",
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+									"notify_when": {
+										Description: "The condition for running the action: onActionGroupChange, onActiveAlert, or onThrottleInterval.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"throttle": {
+										Description: "How often the action runs when notify_when is onThrottleInterval, for example 10m.",
+										Type:        schema.TypeString,
+										Optional:    true,
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -145,6 +170,9 @@ func resourceAlertRuleCreate(ctx context.Context, d *schema.ResourceData, meta i
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if diags = checkAlertActionsVersion(client, alert.Actions); diags.HasError() {
+		return diags
+	}
 	alertId, err = client.CreateAlertRule(alert)
 	if err != nil {
 		return diag.FromErr(err)
@@ -163,6 +191,10 @@ func resourceAlertRuleRead(ctx context.Context, d *schema.ResourceData, meta int
 	client := meta.(mykibana.KibanaAPI)
 	alertId := d.Id()
 	alert, err := client.ReadAlertRule(alertId)
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -218,6 +250,9 @@ func resourceAlertRuleUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if diags = checkAlertActionsVersion(client, alert.Actions); diags.HasError() {
+		return diags
+	}
 	if d.HasChange("enabled") {
 		if enabled := d.Get("enabled").(bool); enabled {
 			err = client.EnableRule(alertId)
@@ -258,6 +293,16 @@ func deflateActions(actionArray []map[string]interface{}) ([]mykibana.Action, er
 		action.Group = group
 		params := flatAction["params"].(string)
 		action.Params = json.RawMessage([]byte(params))
+		if frequencyList, ok := flatAction["frequency"].([]interface{}); ok && len(frequencyList) > 0 && frequencyList[0] != nil {
+			frequency := frequencyList[0].(map[string]interface{})
+			action.Frequency = &mykibana.ActionFrequency{
+				Summary:    frequency["summary"].(bool),
+				NotifyWhen: frequency["notify_when"].(string),
+			}
+			if throttle := frequency["throttle"].(string); throttle != "" {
+				action.Frequency.Throttle = &throttle
+			}
+		}
 		actions = append(actions, action)
 	}
 	return actions, nil
@@ -274,11 +319,32 @@ func flattenActions(actions []mykibana.Action) ([]map[string]interface{}, error)
 			return nil, errors.Wrapf(err, "Failed to marshal Action")
 		}
 		action["params"] = string(paramsBytes)
+		if a.Frequency != nil {
+			frequency := map[string]interface{}{
+				"summary":     a.Frequency.Summary,
+				"notify_when": a.Frequency.NotifyWhen,
+			}
+			if a.Frequency.Throttle != nil {
+				frequency["throttle"] = *a.Frequency.Throttle
+			}
+			action["frequency"] = []map[string]interface{}{frequency}
+		}
 		res = append(res, action)
 	}
 	return res, nil
 }
 
+// checkAlertActionsVersion rejects the action attributes the detected Kibana
+// doesn't support.
+func checkAlertActionsVersion(client mykibana.KibanaAPI, actions []mykibana.Action) diag.Diagnostics {
+	for _, action := range actions {
+		if action.Frequency != nil {
+			return requireKibanaVersion(client, 8, 6, "actions.frequency")
+		}
+	}
+	return nil
+}
+
 func rawJsonEqual(k, oldValue, newValue string, d *schema.ResourceData) bool {
 	var oldInterface, newInterface interface{}
 	if err := json.Unmarshal([]byte(oldValue), &oldInterface); err != nil {
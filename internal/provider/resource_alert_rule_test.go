@@ -2,10 +2,14 @@ package provider_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	myprovider "github.com/qonto/terraform-provider-kibana/internal/provider"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
 )
 
 func TestKibanaAlertRule(t *testing.T) {
@@ -22,6 +26,88 @@ func TestKibanaAlertRule(t *testing.T) {
 	})
 }
 
+func TestKibanaAlertRuleActionFrequencyRequiresKibana86(t *testing.T) {
+	k := mykibana.KibanaMockClient{VersionNumber: "8.5.3"}
+	resource.Test(t, resource.TestCase{
+		Providers: map[string]*schema.Provider{
+			"kibana": myprovider.New(&k)(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      getAlertWithActionFrequencyConfig(),
+				ExpectError: regexp.MustCompile("actions.frequency requires Kibana 8.6 or later"),
+			},
+		},
+	})
+}
+
+// TestKibanaAlertRuleFake runs the real client end to end against an in-memory
+// Kibana, without TF_ACC. See fakeProviders for the Terraform CLI it needs.
+func TestKibanaAlertRuleFake(t *testing.T) {
+	fakeProviders, fake := fakeProviders(t)
+	resource.UnitTest(t, resource.TestCase{
+		Providers: fakeProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig(fake) + getFakeAlertConfig("Test alert", false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAlertExists("kibana_alert_rule.test"),
+					resource.TestCheckResourceAttr("kibana_alert_rule.test", "enabled", "false"),
+				),
+			},
+			{
+				Config: fakeProviderConfig(fake) + getFakeAlertConfig("Renamed alert", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_alert_rule.test", "name", "Renamed alert"),
+					resource.TestCheckResourceAttr("kibana_alert_rule.test", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func getFakeAlertConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+	resource "kibana_alert_rule" "test" {
+    consumer     = "alerts"
+    enabled      = %t
+    name         = "%s"
+    notify_when  = "onActiveAlert"
+    params       = jsonencode({ index = ["logs-*"], timeField = "@timestamp" })
+    rule_type_id = ".index-threshold"
+    schedule     = {
+        "interval" = "1m"
+    }
+    tags         = ["ok"]
+    }
+	`, enabled, name)
+}
+
+func getAlertWithActionFrequencyConfig() string {
+	return `
+	resource "kibana_alert_rule" "test" {
+    consumer     = "alerts"
+    name         = "Test alert"
+    notify_when  = "onActiveAlert"
+    params       = jsonencode({ index = ["logs-*"], timeField = "@timestamp" })
+    rule_type_id = ".index-threshold"
+    schedule     = {
+        "interval" = "1m"
+    }
+    actions {
+        group  = "threshold met"
+        id     = "407ed770-9cf4-47aa-8840-0b5cdb22496e"
+        params = jsonencode({ message = "{{context.message}}" })
+        frequency {
+            summary     = true
+            notify_when = "onThrottleInterval"
+            throttle    = "1h"
+        }
+    }
+    }
+	`
+}
+
 func getAlertConfig() string {
 	return fmt.Sprintf(`
 	resource "kibana_alert_rule" "test" {
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceApmAgentConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the central configuration of the APM agents of a service and environment.",
+
+		CreateContext: resourceApmAgentConfigurationCreate,
+		ReadContext:   resourceApmAgentConfigurationRead,
+		UpdateContext: resourceApmAgentConfigurationUpdate,
+		DeleteContext: resourceApmAgentConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"service_name": {
+				Description: "The name of the service. The configuration applies to all services when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"environment": {
+				Description: "The environment of the service. The configuration applies to all environments when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"agent_name": {
+				Description: "The name of the APM agent of the service, for example java or nodejs. Kibana uses it to check the settings.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"settings": {
+				Description: "The agent settings, for example transaction_sample_rate, capture_body or log_level.",
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceApmAgentConfigurationImport,
+		},
+	}
+}
+
+func resourceApmAgentConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	config := deflateApmAgentConfiguration(d)
+	err := client.PutApmAgentConfiguration(config, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(mykibana.ApmAgentConfigurationId(config.Service.Name, config.Service.Environment))
+	return resourceApmAgentConfigurationRead(ctx, d, meta)
+}
+
+func resourceApmAgentConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	config, err := client.ReadApmAgentConfiguration(d.Get("service_name").(string), d.Get("environment").(string))
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("agent_name", config.AgentName)
+	d.Set("settings", config.Settings)
+
+	return diags
+}
+
+func resourceApmAgentConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	err := client.PutApmAgentConfiguration(deflateApmAgentConfiguration(d), true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceApmAgentConfigurationRead(ctx, d, meta)
+}
+
+func resourceApmAgentConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteApmAgentConfiguration(d.Get("service_name").(string), d.Get("environment").(string))
+	if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourceApmAgentConfigurationImport takes <service_name>:<environment>, or
+// the service name alone for the configurations of all environments. * stands
+// for all services.
+func resourceApmAgentConfigurationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if parts[0] != "*" {
+		d.Set("service_name", parts[0])
+	}
+	if len(parts) == 2 {
+		d.Set("environment", parts[1])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func deflateApmAgentConfiguration(d *schema.ResourceData) mykibana.ApmAgentConfiguration {
+	config := mykibana.ApmAgentConfiguration{}
+	config.Service = mykibana.ApmService{
+		Name:        d.Get("service_name").(string),
+		Environment: d.Get("environment").(string),
+	}
+	config.AgentName = d.Get("agent_name").(string)
+	config.Settings = make(map[string]string)
+	for key, value := range d.Get("settings").(map[string]interface{}) {
+		config.Settings[key] = value.(string)
+	}
+	return config
+}
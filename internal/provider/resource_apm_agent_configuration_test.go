@@ -0,0 +1,62 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaApmAgentConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getApmAgentConfigurationConfig("0.5"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckApmAgentConfigurationExists("kibana_apm_agent_configuration.test"),
+					resource.TestCheckResourceAttr("kibana_apm_agent_configuration.test", "id", "checkout:production"),
+					resource.TestCheckResourceAttr("kibana_apm_agent_configuration.test", "settings.transaction_sample_rate", "0.5"),
+				),
+			},
+			{
+				Config: getApmAgentConfigurationConfig("0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_apm_agent_configuration.test", "settings.transaction_sample_rate", "0.1"),
+				),
+			},
+		},
+	})
+}
+
+func getApmAgentConfigurationConfig(sampleRate string) string {
+	return fmt.Sprintf(`
+	resource "kibana_apm_agent_configuration" "test" {
+    service_name = "checkout"
+    environment  = "production"
+    agent_name   = "java"
+    settings = {
+      transaction_sample_rate = "%s"
+      capture_body            = "errors"
+      log_level               = "info"
+    }
+    }
+	`, sampleRate)
+}
+
+func testCheckApmAgentConfigurationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No APM agent configuration ID set")
+		}
+
+		return nil
+	}
+}
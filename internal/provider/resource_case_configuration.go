@@ -0,0 +1,386 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceCaseConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the configuration of the cases of an owner in a space: the connector cases are pushed to, the closure type, the custom fields and the templates. Kibana can't delete configurations, destroying the resource resets it.",
+
+		CreateContext: resourceCaseConfigurationCreate,
+		ReadContext:   resourceCaseConfigurationRead,
+		UpdateContext: resourceCaseConfigurationUpdate,
+		DeleteContext: resourceCaseConfigurationDelete,
+		CustomizeDiff: resourceCaseConfigurationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description: "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"owner": {
+				Description:  "The application owning the cases: cases, observability or securitySolution.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"cases", "observability", "securitySolution"}, false),
+			},
+			"connector": {
+				Description: "The connector cases are pushed to. Cases are not pushed when not provided.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the connector.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"name": {
+							Description: "The name of the connector.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"type": {
+							Description: "The type of the connector, for example .jira, .servicenow or .swimlane.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"closure_type": {
+				Description:  "Closes cases when they are pushed (close-by-pushing) or only by users (close-by-user).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "close-by-user",
+				ValidateFunc: validation.StringInSlice([]string{"close-by-user", "close-by-pushing"}, false),
+			},
+			"custom_field": {
+				Description: "The custom fields of the cases. Requires Kibana 8.13 or later.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Description: "The unique identifier of the field.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"label": {
+							Description: "The label of the field.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"type": {
+							Description:  "The type of the field: text, toggle or number.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"text", "toggle", "number"}, false),
+						},
+						"required": {
+							Description: "Makes the field required.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"default_value": {
+							Description: "The default value of the field, true or false for toggle fields.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"template": {
+				Description: "The templates cases can be created from. Requires Kibana 8.15 or later.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Description: "The unique identifier of the template.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"name": {
+							Description: "The name of the template.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"description": {
+							Description: "The description of the template.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"tags": {
+							Description: "The tags of the template.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"case_fields": {
+							Description:      "The fields of the cases created from the template as JSON, such as title, severity, tags or customFields.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: rawJsonEqual,
+							ValidateFunc:     validation.StringIsJSON,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithSpace,
+		},
+	}
+}
+
+func resourceCaseConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	config, err := deflateCaseConfiguration(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	created, err := client.CreateCaseConfiguration(d.Get("space_id").(string), config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(created.Id)
+	return resourceCaseConfigurationRead(ctx, d, meta)
+}
+
+func resourceCaseConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	config, err := readCaseConfiguration(client, d.Get("space_id").(string), d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("owner", config.Owner)
+	if config.Connector.Type == "" || config.Connector.Type == mykibana.NoneCaseConnector.Type {
+		d.Set("connector", nil)
+	} else {
+		d.Set("connector", []map[string]interface{}{{
+			"id":   config.Connector.Id,
+			"name": config.Connector.Name,
+			"type": config.Connector.Type,
+		}})
+	}
+	d.Set("closure_type", config.ClosureType)
+	customFields := []map[string]interface{}{}
+	for _, f := range caseCustomFields(config) {
+		customFields = append(customFields, map[string]interface{}{
+			"key":           f.Key,
+			"label":         f.Label,
+			"type":          f.Type,
+			"required":      f.Required,
+			"default_value": flattenCaseCustomFieldValue(f.DefaultValue),
+		})
+	}
+	d.Set("custom_field", customFields)
+	templates := []map[string]interface{}{}
+	for _, t := range caseTemplates(config) {
+		templates = append(templates, map[string]interface{}{
+			"key":         t.Key,
+			"name":        t.Name,
+			"description": t.Description,
+			"tags":        t.Tags,
+			"case_fields": flattenRawJson(t.CaseFields),
+		})
+	}
+	d.Set("template", templates)
+
+	return diags
+}
+
+func resourceCaseConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	config, err := deflateCaseConfiguration(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = updateCaseConfiguration(client, d.Get("space_id").(string), d.Id(), config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceCaseConfigurationRead(ctx, d, meta)
+}
+
+func resourceCaseConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	config := mykibana.CaseConfiguration{
+		Connector:   mykibana.NoneCaseConnector,
+		ClosureType: "close-by-user",
+	}
+	if len(d.Get("custom_field").([]interface{})) > 0 {
+		config.CustomFields = &[]mykibana.CaseCustomField{}
+	}
+	if len(d.Get("template").([]interface{})) > 0 {
+		config.Templates = &[]mykibana.CaseTemplate{}
+	}
+	err := updateCaseConfiguration(client, d.Get("space_id").(string), d.Id(), config)
+	if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func readCaseConfiguration(client mykibana.KibanaAPI, spaceId, configurationId string) (mykibana.CaseConfiguration, error) {
+	configs, err := client.ListCaseConfigurations(spaceId)
+	if err != nil {
+		return mykibana.CaseConfiguration{}, err
+	}
+	for _, config := range configs {
+		if config.Id == configurationId {
+			return config, nil
+		}
+	}
+	return mykibana.CaseConfiguration{}, errors.Wrapf(mykibana.ErrNotFound, "Case configuration %s", configurationId)
+}
+
+// updateCaseConfiguration reads the current version of the configuration,
+// which Kibana requires to update it.
+func updateCaseConfiguration(client mykibana.KibanaAPI, spaceId, configurationId string, config mykibana.CaseConfiguration) error {
+	current, err := readCaseConfiguration(client, spaceId, configurationId)
+	if err != nil {
+		return err
+	}
+	config.Version = current.Version
+	_, err = client.UpdateCaseConfiguration(spaceId, configurationId, config)
+	return err
+}
+
+func deflateCaseConfiguration(d *schema.ResourceData) (mykibana.CaseConfiguration, error) {
+	config := mykibana.CaseConfiguration{}
+	config.Owner = d.Get("owner").(string)
+	config.Connector = mykibana.NoneCaseConnector
+	if connectorList := d.Get("connector").([]interface{}); len(connectorList) > 0 && connectorList[0] != nil {
+		connector := connectorList[0].(map[string]interface{})
+		config.Connector = mykibana.CaseConnector{
+			Id:     connector["id"].(string),
+			Name:   connector["name"].(string),
+			Type:   connector["type"].(string),
+			Fields: json.RawMessage("null"),
+		}
+	}
+	config.ClosureType = d.Get("closure_type").(string)
+	customFields := []mykibana.CaseCustomField{}
+	for _, flatField := range d.Get("custom_field").([]interface{}) {
+		field := flatField.(map[string]interface{})
+		defaultValue, err := deflateCaseCustomFieldValue(field["type"].(string), field["default_value"].(string))
+		if err != nil {
+			return config, errors.Wrapf(err, "Custom field %s", field["key"])
+		}
+		customFields = append(customFields, mykibana.CaseCustomField{
+			Key:          field["key"].(string),
+			Label:        field["label"].(string),
+			Type:         field["type"].(string),
+			Required:     field["required"].(bool),
+			DefaultValue: defaultValue,
+		})
+	}
+	// Custom fields and templates are only sent when configured, or to clear
+	// the ones of the state.
+	if oldCustomFields, _ := d.GetChange("custom_field"); len(customFields) > 0 || len(oldCustomFields.([]interface{})) > 0 {
+		config.CustomFields = &customFields
+	}
+	templates := []mykibana.CaseTemplate{}
+	for _, flatTemplate := range d.Get("template").([]interface{}) {
+		template := flatTemplate.(map[string]interface{})
+		caseTemplate := mykibana.CaseTemplate{
+			Key:         template["key"].(string),
+			Name:        template["name"].(string),
+			Description: template["description"].(string),
+			Tags:        deflateStringList(template["tags"].([]interface{})),
+		}
+		if caseFields := template["case_fields"].(string); caseFields != "" {
+			caseTemplate.CaseFields = json.RawMessage(caseFields)
+		}
+		templates = append(templates, caseTemplate)
+	}
+	if oldTemplates, _ := d.GetChange("template"); len(templates) > 0 || len(oldTemplates.([]interface{})) > 0 {
+		config.Templates = &templates
+	}
+	return config, nil
+}
+
+// resourceCaseConfigurationCustomizeDiff rejects the custom fields and the
+// templates the detected Kibana doesn't support.
+func resourceCaseConfigurationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(mykibana.KibanaAPI)
+	if len(d.Get("custom_field").([]interface{})) > 0 {
+		if err := checkKibanaVersion(client, 8, 13, "custom_field"); err != nil {
+			return err
+		}
+	}
+	if len(d.Get("template").([]interface{})) > 0 {
+		if err := checkKibanaVersion(client, 8, 15, "template"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func caseCustomFields(config mykibana.CaseConfiguration) []mykibana.CaseCustomField {
+	if config.CustomFields == nil {
+		return nil
+	}
+	return *config.CustomFields
+}
+
+func caseTemplates(config mykibana.CaseConfiguration) []mykibana.CaseTemplate {
+	if config.Templates == nil {
+		return nil
+	}
+	return *config.Templates
+}
+
+// deflateCaseCustomFieldValue converts the default value to the JSON type of
+// the custom field.
+func deflateCaseCustomFieldValue(fieldType, value string) (json.RawMessage, error) {
+	if value == "" {
+		return nil, nil
+	}
+	switch fieldType {
+	case "toggle":
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("Invalid default value %s, expected true or false", value)
+		}
+		return json.RawMessage(value), nil
+	case "number":
+		// ParseFloat also accepts values such as +1, .5, NaN or 0x1p4, which
+		// aren't JSON numbers, and fails on the ones out of range.
+		if _, err := strconv.ParseFloat(value, 64); err != nil || !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("Invalid default value %s, expected a number", value)
+		}
+		return json.RawMessage(value), nil
+	}
+	return json.Marshal(value)
+}
+
+func flattenCaseCustomFieldValue(value json.RawMessage) string {
+	if len(value) == 0 || string(value) == "null" {
+		return ""
+	}
+	return flattenSettingValue(value)
+}
@@ -0,0 +1,138 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	myprovider "github.com/qonto/terraform-provider-kibana/internal/provider"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func TestKibanaCaseConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getCaseConfigurationConfig("close-by-user"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckCaseConfigurationExists("kibana_case_configuration.test"),
+					resource.TestCheckResourceAttr("kibana_case_configuration.test", "custom_field.#", "1"),
+					resource.TestCheckResourceAttr("kibana_case_configuration.test", "template.0.name", "Incident"),
+				),
+			},
+			{
+				Config: getCaseConfigurationConfig("close-by-pushing"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_case_configuration.test", "closure_type", "close-by-pushing"),
+				),
+			},
+		},
+	})
+}
+
+func getCaseConfigurationConfig(closureType string) string {
+	return fmt.Sprintf(`
+	resource "kibana_case_configuration" "test" {
+    owner        = "cases"
+    closure_type = "%s"
+    custom_field {
+      key           = "customer_impact"
+      label         = "Customer impact"
+      type          = "toggle"
+      default_value = "false"
+    }
+    template {
+      key         = "incident"
+      name        = "Incident"
+      tags        = ["incident"]
+      case_fields = jsonencode({ severity = "high" })
+    }
+    }
+	`, closureType)
+}
+
+func TestKibanaCaseConfigurationRequiresKibanaVersion(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		err     string
+	}{
+		{"8.12.2", "custom_field requires Kibana 8.13 or later"},
+		{"8.14.3", "template requires Kibana 8.15 or later"},
+	} {
+		k := mykibana.KibanaMockClient{VersionNumber: tc.version}
+		resource.Test(t, resource.TestCase{
+			Providers: map[string]*schema.Provider{
+				"kibana": myprovider.New(&k)(),
+			},
+			Steps: []resource.TestStep{
+				{
+					Config:      getCaseConfigurationConfig("close-by-user"),
+					ExpectError: regexp.MustCompile(tc.err),
+				},
+			},
+		})
+	}
+}
+
+func testCheckCaseConfigurationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No case configuration ID set")
+		}
+
+		return nil
+	}
+}
+
+func TestKibanaCaseConfigurationCustomFieldDefaultValue(t *testing.T) {
+	for _, tc := range []struct {
+		fieldType string
+		value     string
+		valid     bool
+	}{
+		{"toggle", "true", true},
+		{"toggle", "false", true},
+		{"toggle", "t", false},
+		{"toggle", "1", false},
+		{"toggle", "TRUE", false},
+		{"number", "42", true},
+		{"number", "-0.5e3", true},
+		{"number", "NaN", false},
+		{"number", "+1", false},
+		{"number", ".5", false},
+		{"number", "0x1p4", false},
+		{"number", "1e999", false},
+		{"text", "0x1p4", true},
+	} {
+		client := &mykibana.KibanaMockClient{}
+		r := provider.ResourcesMap["kibana_case_configuration"]
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"owner": "cases",
+			"custom_field": []interface{}{map[string]interface{}{
+				"key":           "field",
+				"label":         "Field",
+				"type":          tc.fieldType,
+				"default_value": tc.value,
+			}},
+		})
+		diags := r.CreateContext(context.Background(), d, client)
+		if diags.HasError() == tc.valid {
+			t.Errorf("%s default value %q: valid %v, got %v", tc.fieldType, tc.value, tc.valid, diags)
+			continue
+		}
+		if tc.valid && d.Get("custom_field.0.default_value").(string) != tc.value {
+			t.Errorf("%s default value %q read back as %q", tc.fieldType, tc.value, d.Get("custom_field.0.default_value"))
+		}
+	}
+}
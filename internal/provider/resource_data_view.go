@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceDataView() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Kibana data view (formerly index pattern).",
+
+		CreateContext: resourceDataViewCreate,
+		ReadContext:   resourceDataViewRead,
+		UpdateContext: resourceDataViewUpdate,
+		DeleteContext: resourceDataViewDelete,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description: "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"title": {
+				Description: "Comma-separated list of data streams, indices, and aliases that you want to search. Supports wildcards (*).",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "The data view name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"time_field_name": {
+				Description: "The timestamp field name, which you use for time-based data views.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"source_filters": {
+				Description: "The list of field names to exclude from the documents source.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"field_formats": {
+				Description:      "A JSON object of field formats, keyed by field name.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: rawJsonEqual,
+			},
+			"runtime_field_map": {
+				Description:      "A JSON object of runtime field definitions, keyed by field name.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: rawJsonEqual,
+			},
+			"field_attrs": {
+				Description:      "A JSON object of field attributes (custom label, popularity count), keyed by field name.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: rawJsonEqual,
+			},
+			"allow_no_index": {
+				Description: "Allows the data view saved object to exist before the data is available.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"namespaces": {
+				Description: "An array of space identifiers for sharing the data view between multiple spaces.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithSpace,
+		},
+	}
+}
+
+func resourceDataViewCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	dataView := deflateDataView(d)
+	for _, namespace := range d.Get("namespaces").([]interface{}) {
+		dataView.Namespaces = append(dataView.Namespaces, namespace.(string))
+	}
+	created, err := client.CreateDataView(d.Get("space_id").(string), dataView)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(created.Id)
+	return resourceDataViewRead(ctx, d, meta)
+}
+
+func resourceDataViewRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	dataView, err := client.ReadDataView(d.Get("space_id").(string), d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sourceFilters := make([]string, 0, len(dataView.SourceFilters))
+	for _, filter := range dataView.SourceFilters {
+		sourceFilters = append(sourceFilters, filter.Value)
+	}
+	d.Set("title", dataView.Title)
+	d.Set("name", dataView.Name)
+	d.Set("time_field_name", dataView.TimeFieldName)
+	d.Set("source_filters", sourceFilters)
+	d.Set("field_formats", flattenRawJson(dataView.FieldFormats))
+	d.Set("runtime_field_map", flattenRawJson(dataView.RuntimeFieldMap))
+	d.Set("field_attrs", flattenRawJson(dataView.FieldAttrs))
+	d.Set("allow_no_index", dataView.AllowNoIndex)
+	d.Set("namespaces", dataView.Namespaces)
+
+	return diags
+}
+
+func resourceDataViewUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	_, err := client.UpdateDataView(d.Get("space_id").(string), d.Id(), deflateDataView(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceDataViewRead(ctx, d, meta)
+}
+
+func resourceDataViewDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteDataView(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateDataView(d *schema.ResourceData) mykibana.DataView {
+	dataView := mykibana.DataView{}
+	dataView.Title = d.Get("title").(string)
+	dataView.Name = d.Get("name").(string)
+	dataView.TimeFieldName = d.Get("time_field_name").(string)
+	dataView.SourceFilters = []mykibana.SourceFilter{}
+	for _, filter := range d.Get("source_filters").([]interface{}) {
+		dataView.SourceFilters = append(dataView.SourceFilters, mykibana.SourceFilter{Value: filter.(string)})
+	}
+	dataView.FieldFormats = deflateRawJsonObject(d.Get("field_formats").(string))
+	dataView.RuntimeFieldMap = deflateRawJsonObject(d.Get("runtime_field_map").(string))
+	dataView.FieldAttrs = deflateRawJsonObject(d.Get("field_attrs").(string))
+	dataView.AllowNoIndex = d.Get("allow_no_index").(bool)
+	return dataView
+}
+
+// deflateRawJsonObject sends an empty object for unset JSON attributes so that
+// removing them from the configuration clears them in Kibana.
+func deflateRawJsonObject(value string) json.RawMessage {
+	if value == "" {
+		return json.RawMessage([]byte("{}"))
+	}
+	return json.RawMessage([]byte(value))
+}
+
+// flattenRawJson returns the string form of a JSON value read from Kibana,
+// treating null and empty objects as unset.
+func flattenRawJson(raw json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil || value == nil {
+		return ""
+	}
+	if object, ok := value.(map[string]interface{}); ok && len(object) == 0 {
+		return ""
+	}
+	return string(raw)
+}
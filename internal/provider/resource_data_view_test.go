@@ -0,0 +1,79 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaDataView(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getDataViewConfig("logs-*"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckDataViewExists("kibana_data_view.test"),
+					resource.TestCheckResourceAttr("kibana_data_view.test", "title", "logs-*"),
+					resource.TestCheckResourceAttr("kibana_data_view.test", "source_filters.#", "1"),
+				),
+			},
+			{
+				Config: getDataViewConfig("logs-*,metrics-*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_data_view.test", "title", "logs-*,metrics-*"),
+				),
+			},
+		},
+	})
+}
+
+func getDataViewConfig(title string) string {
+	return fmt.Sprintf(`
+	resource "kibana_data_view" "test" {
+    title           = "%s"
+    name            = "Logs"
+    time_field_name = "@timestamp"
+    source_filters  = ["secret.*"]
+    field_formats   = jsonencode(
+        {
+            "event.duration" = {
+                id     = "duration"
+                params = {
+                    inputFormat  = "nanoseconds"
+                    outputFormat = "asMilliseconds"
+                }
+            }
+        }
+    )
+    runtime_field_map = jsonencode(
+        {
+            day_of_week = {
+                type   = "keyword"
+                script = {
+                    source = "emit(doc['@timestamp'].value.dayOfWeekEnum.getDisplayName(TextStyle.FULL, Locale.ROOT))"
+                }
+            }
+        }
+    )
+    }
+	`, title)
+}
+
+func testCheckDataViewExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No data view ID set")
+		}
+
+		return nil
+	}
+}
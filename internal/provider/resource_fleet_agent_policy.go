@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceFleetAgentPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Fleet agent policy.",
+
+		CreateContext: resourceFleetAgentPolicyCreate,
+		ReadContext:   resourceFleetAgentPolicyRead,
+		UpdateContext: resourceFleetAgentPolicyUpdate,
+		DeleteContext: resourceFleetAgentPolicyDelete,
+		CustomizeDiff: resourceFleetAgentPolicyCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the agent policy.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"namespace": {
+				Description: "The namespace of the data streams written by the agents, for example default.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The description of the agent policy.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"monitoring_enabled": {
+				Description: "The agent monitoring to collect: logs, metrics or both.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"logs", "metrics"}, false),
+				},
+			},
+			"data_output_id": {
+				Description: "The ID of the output the agents send their data to. The default output is used when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"monitoring_output_id": {
+				Description: "The ID of the output the agents send their monitoring data to. The default output is used when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"fleet_server_host_id": {
+				Description: "The ID of the Fleet Server host the agents enroll with. The default Fleet Server host is used when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"inactivity_timeout": {
+				Description: "The number of seconds after which an agent not checking in is considered inactive.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"is_protected": {
+				Description: "Prevents agents enrolled in the policy from being uninstalled without an uninstall token. Requires Kibana 8.10 or later.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceFleetAgentPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	policy, err := client.CreateFleetAgentPolicy(deflateFleetAgentPolicy(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(policy.Id)
+	return resourceFleetAgentPolicyRead(ctx, d, meta)
+}
+
+func resourceFleetAgentPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	policy, err := client.ReadFleetAgentPolicy(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("name", policy.Name)
+	d.Set("namespace", policy.Namespace)
+	d.Set("description", policy.Description)
+	d.Set("monitoring_enabled", policy.MonitoringEnabled)
+	d.Set("data_output_id", stringValue(policy.DataOutputId))
+	d.Set("monitoring_output_id", stringValue(policy.MonitoringOutputId))
+	d.Set("fleet_server_host_id", stringValue(policy.FleetServerHostId))
+	d.Set("inactivity_timeout", policy.InactivityTimeout)
+	d.Set("is_protected", policy.IsProtected != nil && *policy.IsProtected)
+
+	return diags
+}
+
+func resourceFleetAgentPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	_, err := client.UpdateFleetAgentPolicy(d.Id(), deflateFleetAgentPolicy(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFleetAgentPolicyRead(ctx, d, meta)
+}
+
+func resourceFleetAgentPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteFleetAgentPolicy(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateFleetAgentPolicy(d *schema.ResourceData) mykibana.FleetAgentPolicy {
+	policy := mykibana.FleetAgentPolicy{}
+	policy.Name = d.Get("name").(string)
+	policy.Namespace = d.Get("namespace").(string)
+	policy.Description = d.Get("description").(string)
+	policy.MonitoringEnabled = deflateStringSet(d.Get("monitoring_enabled").(*schema.Set))
+	policy.DataOutputId = optionalString(d.Get("data_output_id").(string))
+	policy.MonitoringOutputId = optionalString(d.Get("monitoring_output_id").(string))
+	policy.FleetServerHostId = optionalString(d.Get("fleet_server_host_id").(string))
+	policy.InactivityTimeout = d.Get("inactivity_timeout").(int)
+	// is_protected is only sent when enabled, or to disable it.
+	isProtected := d.Get("is_protected").(bool)
+	if wasProtected, _ := d.GetChange("is_protected"); isProtected || wasProtected.(bool) {
+		policy.IsProtected = &isProtected
+	}
+	return policy
+}
+
+// resourceFleetAgentPolicyCustomizeDiff rejects the attributes the detected
+// Kibana doesn't support.
+func resourceFleetAgentPolicyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("is_protected").(bool) {
+		return checkKibanaVersion(meta.(mykibana.KibanaAPI), 8, 10, "is_protected")
+	}
+	return nil
+}
+
+// optionalString returns nil for an empty string, which the Fleet API reads
+// as unset.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
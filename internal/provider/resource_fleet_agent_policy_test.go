@@ -0,0 +1,104 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	myprovider "github.com/qonto/terraform-provider-kibana/internal/provider"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func TestKibanaFleetAgentPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getFleetAgentPolicyConfig("Servers"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckFleetAgentPolicyExists("kibana_fleet_agent_policy.test"),
+					resource.TestCheckResourceAttr("kibana_fleet_agent_policy.test", "monitoring_enabled.#", "2"),
+				),
+			},
+			{
+				Config: getFleetAgentPolicyConfig("Linux servers"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_agent_policy.test", "name", "Linux servers"),
+				),
+			},
+			{
+				Config: getFleetAgentPolicyWithOutputConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_agent_policy.test", "data_output_id", "logstash"),
+				),
+			},
+			{
+				Config: getFleetAgentPolicyConfig("Linux servers"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_agent_policy.test", "data_output_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func getFleetAgentPolicyConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_agent_policy" "test" {
+    name               = "%s"
+    namespace          = "production"
+    description        = "Agents running on our servers"
+    monitoring_enabled = ["logs", "metrics"]
+    inactivity_timeout = 1209600
+    is_protected       = true
+    }
+	`, name)
+}
+
+func getFleetAgentPolicyWithOutputConfig() string {
+	return `
+	resource "kibana_fleet_agent_policy" "test" {
+    name               = "Linux servers"
+    namespace          = "production"
+    description        = "Agents running on our servers"
+    monitoring_enabled = ["logs", "metrics"]
+    data_output_id     = "logstash"
+    inactivity_timeout = 1209600
+    is_protected       = true
+    }
+	`
+}
+
+func TestKibanaFleetAgentPolicyIsProtectedRequiresKibana810(t *testing.T) {
+	k := mykibana.KibanaMockClient{VersionNumber: "8.9.2"}
+	resource.Test(t, resource.TestCase{
+		Providers: map[string]*schema.Provider{
+			"kibana": myprovider.New(&k)(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      getFleetAgentPolicyConfig("Servers"),
+				ExpectError: regexp.MustCompile("is_protected requires Kibana 8.10 or later"),
+			},
+		},
+	})
+}
+
+func testCheckFleetAgentPolicyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No agent policy ID set")
+		}
+
+		return nil
+	}
+}
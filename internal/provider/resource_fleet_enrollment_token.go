@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+// fleetEnrollmentTokenName matches the token names of Kibana, which appends
+// the ID of the API key to the name given on creation, if any.
+var fleetEnrollmentTokenName = regexp.MustCompile(`^(?:(.*) \([0-9a-f-]{36}\)|[0-9a-f-]{36})$`)
+
+func resourceFleetEnrollmentToken() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Fleet enrollment token of an agent policy. Destroying the resource revokes the token.",
+
+		CreateContext: resourceFleetEnrollmentTokenCreate,
+		ReadContext:   resourceFleetEnrollmentTokenRead,
+		DeleteContext: resourceFleetEnrollmentTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the token. Kibana appends a unique suffix to it.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"policy_id": {
+				Description: "The ID of the agent policy the token enrolls agents in.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"token": {
+				Description: "The enrollment token, passed to elastic-agent enroll.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"api_key_id": {
+				Description: "The ID of the Elasticsearch API key backing the token.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"full_name": {
+				Description: "The name of the token in Kibana, including its unique suffix.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "The creation date of the token.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceFleetEnrollmentTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	token, err := client.CreateFleetEnrollmentToken(mykibana.FleetEnrollmentToken{
+		Name:     d.Get("name").(string),
+		PolicyId: d.Get("policy_id").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(token.Id)
+	return resourceFleetEnrollmentTokenRead(ctx, d, meta)
+}
+
+func resourceFleetEnrollmentTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	token, err := client.ReadFleetEnrollmentToken(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Revoked tokens are kept by Kibana, they are recreated like deleted ones.
+	if !token.Active {
+		d.SetId("")
+		return diags
+	}
+	if match := fleetEnrollmentTokenName.FindStringSubmatch(token.Name); match != nil {
+		d.Set("name", match[1])
+	}
+	d.Set("policy_id", token.PolicyId)
+	d.Set("token", token.ApiKey)
+	d.Set("api_key_id", token.ApiKeyId)
+	d.Set("full_name", token.Name)
+	d.Set("created_at", token.CreatedAt)
+
+	return diags
+}
+
+func resourceFleetEnrollmentTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.RevokeFleetEnrollmentToken(d.Id())
+	if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,66 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaFleetEnrollmentToken(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getFleetEnrollmentTokenConfig("bootstrap"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckFleetEnrollmentTokenExists("kibana_fleet_enrollment_token.test"),
+					resource.TestCheckResourceAttrSet("kibana_fleet_enrollment_token.test", "token"),
+					resource.TestCheckResourceAttrPair("kibana_fleet_enrollment_token.test", "policy_id", "kibana_fleet_agent_policy.test", "id"),
+				),
+			},
+			{
+				Config: getFleetEnrollmentTokenConfig("cloud-init"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_enrollment_token.test", "name", "cloud-init"),
+				),
+			},
+			{
+				ResourceName:      "kibana_fleet_enrollment_token.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func getFleetEnrollmentTokenConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_agent_policy" "test" {
+    name      = "Enrollment tokens"
+    namespace = "default"
+    }
+
+	resource "kibana_fleet_enrollment_token" "test" {
+    name      = "%s"
+    policy_id = kibana_fleet_agent_policy.test.id
+    }
+	`, name)
+}
+
+func testCheckFleetEnrollmentTokenExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No enrollment token ID set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceFleetIntegrationPolicy() *schema.Resource {
+	varsSchema := func(description string, sensitive bool) *schema.Schema {
+		return &schema.Schema{
+			Description:      description,
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        sensitive,
+			DiffSuppressFunc: rawJsonEqual,
+		}
+	}
+	return &schema.Resource{
+		Description: "Manages a Fleet integration policy (package policy), adding an integration to an agent policy.",
+
+		CreateContext: resourceFleetIntegrationPolicyCreate,
+		ReadContext:   resourceFleetIntegrationPolicyRead,
+		UpdateContext: resourceFleetIntegrationPolicyUpdate,
+		DeleteContext: resourceFleetIntegrationPolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the integration policy.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"namespace": {
+				Description: "The namespace of the data streams. The namespace of the agent policy is used when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": {
+				Description: "The description of the integration policy.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"agent_policy_id": {
+				Description: "The ID of the agent policy the integration is added to.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"integration_name": {
+				Description: "The name of the integration package, for example system or nginx.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"integration_version": {
+				Description: "The version of the integration package. The package must be installed, changing the version upgrades the policy in place.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"vars":        varsSchema("The package level variables as JSON. Only the declared variables are compared with Kibana.", false),
+			"secret_vars": varsSchema("The package level secret variables as JSON. Kibana doesn't return secrets, so they are never compared.", true),
+			"input": {
+				Description: "The inputs to configure. Inputs which aren't declared keep their default configuration.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"input_id": {
+							Description: "The input identifier, for example nginx-logfile.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"enabled": {
+							Description: "Enables the input.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"vars":        varsSchema("The input variables as JSON.", false),
+						"secret_vars": varsSchema("The input secret variables as JSON.", true),
+						"stream": {
+							Description: "The input streams to configure.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"stream_id": {
+										Description: "The stream identifier, for example nginx.access.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"enabled": {
+										Description: "Enables the stream.",
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Default:     true,
+									},
+									"vars":        varsSchema("The stream variables as JSON.", false),
+									"secret_vars": varsSchema("The stream secret variables as JSON.", true),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceFleetIntegrationPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	policy, err := deflateFleetIntegrationPolicy(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	created, err := client.CreateFleetPackagePolicy(policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(created.Id)
+	return resourceFleetIntegrationPolicyRead(ctx, d, meta)
+}
+
+func resourceFleetIntegrationPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	policy, err := client.ReadFleetPackagePolicy(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("name", policy.Name)
+	d.Set("namespace", policy.Namespace)
+	d.Set("description", policy.Description)
+	d.Set("agent_policy_id", policy.PolicyId)
+	d.Set("integration_name", policy.Package.Name)
+	d.Set("integration_version", policy.Package.Version)
+	d.Set("vars", flattenDeclaredVars(d.Get("vars").(string), policy.Vars))
+	d.Set("input", flattenFleetIntegrationPolicyInputs(d.Get("input").([]interface{}), policy.Inputs))
+
+	return diags
+}
+
+func resourceFleetIntegrationPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	policy, err := deflateFleetIntegrationPolicy(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if d.HasChange("integration_version") {
+		err = client.UpgradeFleetPackagePolicy(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// Fleet upgrades to the installed version of the package, which must
+		// be installed before the policy is updated.
+		upgraded, err := client.ReadFleetPackagePolicy(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if upgraded.Package.Version != policy.Package.Version {
+			return diag.FromErr(fmt.Errorf("Package policy upgraded to %s %s, the installed version, instead of %s", policy.Package.Name, upgraded.Package.Version, policy.Package.Version))
+		}
+	}
+	_, err = client.UpdateFleetPackagePolicy(d.Id(), policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFleetIntegrationPolicyRead(ctx, d, meta)
+}
+
+func resourceFleetIntegrationPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteFleetPackagePolicy(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateFleetIntegrationPolicy(d *schema.ResourceData) (mykibana.FleetPackagePolicy, error) {
+	var err error
+	policy := mykibana.FleetPackagePolicy{}
+	policy.Name = d.Get("name").(string)
+	policy.Namespace = d.Get("namespace").(string)
+	policy.Description = d.Get("description").(string)
+	policy.PolicyId = d.Get("agent_policy_id").(string)
+	policy.Package = mykibana.FleetPackageReference{
+		Name:    d.Get("integration_name").(string),
+		Version: d.Get("integration_version").(string),
+	}
+	policy.Vars, err = deflateVars(d.Get("vars").(string), d.Get("secret_vars").(string))
+	if err != nil {
+		return policy, err
+	}
+	policy.Inputs = make(map[string]mykibana.FleetPackagePolicyInput)
+	for _, flatInput := range d.Get("input").([]interface{}) {
+		input := flatInput.(map[string]interface{})
+		policyInput := mykibana.FleetPackagePolicyInput{Enabled: input["enabled"].(bool)}
+		policyInput.Vars, err = deflateVars(input["vars"].(string), input["secret_vars"].(string))
+		if err != nil {
+			return policy, errors.Wrapf(err, "Input %s", input["input_id"])
+		}
+		policyInput.Streams = make(map[string]mykibana.FleetPackagePolicyStream)
+		for _, flatStream := range input["stream"].([]interface{}) {
+			stream := flatStream.(map[string]interface{})
+			policyStream := mykibana.FleetPackagePolicyStream{Enabled: stream["enabled"].(bool)}
+			policyStream.Vars, err = deflateVars(stream["vars"].(string), stream["secret_vars"].(string))
+			if err != nil {
+				return policy, errors.Wrapf(err, "Stream %s", stream["stream_id"])
+			}
+			policyInput.Streams[stream["stream_id"].(string)] = policyStream
+		}
+		policy.Inputs[input["input_id"].(string)] = policyInput
+	}
+	return policy, nil
+}
+
+// deflateVars merges the plain and secret variables, both given as JSON
+// objects.
+func deflateVars(vars, secretVars string) (map[string]json.RawMessage, error) {
+	merged := make(map[string]json.RawMessage)
+	for _, jsonVars := range []string{vars, secretVars} {
+		if jsonVars == "" {
+			continue
+		}
+		var decoded map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(jsonVars), &decoded); err != nil {
+			return nil, errors.Wrapf(err, "Invalid vars")
+		}
+		for key, value := range decoded {
+			merged[key] = value
+		}
+	}
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return merged, nil
+}
+
+// flattenDeclaredVars keeps the variables declared in the configuration out of
+// the ones returned by Kibana, which include every package default.
+func flattenDeclaredVars(declared string, vars map[string]json.RawMessage) string {
+	var declaredVars map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(declared), &declaredVars); err != nil || len(declaredVars) == 0 {
+		return declared
+	}
+	res := make(map[string]json.RawMessage)
+	for key := range declaredVars {
+		if value, ok := vars[key]; ok {
+			res[key] = value
+		}
+	}
+	resBytes, err := json.Marshal(res)
+	if err != nil {
+		return declared
+	}
+	return string(resBytes)
+}
+
+func flattenFleetIntegrationPolicyInputs(declared []interface{}, inputs map[string]mykibana.FleetPackagePolicyInput) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(declared))
+	for _, flatInput := range declared {
+		declaredInput := flatInput.(map[string]interface{})
+		i, ok := inputs[declaredInput["input_id"].(string)]
+		if !ok {
+			continue
+		}
+		input := make(map[string]interface{})
+		input["input_id"] = declaredInput["input_id"]
+		input["enabled"] = i.Enabled
+		input["vars"] = flattenDeclaredVars(declaredInput["vars"].(string), i.Vars)
+		input["secret_vars"] = declaredInput["secret_vars"]
+		streams := make([]map[string]interface{}, 0)
+		for _, flatStream := range declaredInput["stream"].([]interface{}) {
+			declaredStream := flatStream.(map[string]interface{})
+			s, ok := i.Streams[declaredStream["stream_id"].(string)]
+			if !ok {
+				continue
+			}
+			stream := make(map[string]interface{})
+			stream["stream_id"] = declaredStream["stream_id"]
+			stream["enabled"] = s.Enabled
+			stream["vars"] = flattenDeclaredVars(declaredStream["vars"].(string), s.Vars)
+			stream["secret_vars"] = declaredStream["secret_vars"]
+			streams = append(streams, stream)
+		}
+		input["stream"] = streams
+		res = append(res, input)
+	}
+	return res
+}
@@ -0,0 +1,78 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaFleetIntegrationPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getFleetIntegrationPolicyConfig("1.12.0"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckFleetIntegrationPolicyExists("kibana_fleet_integration_policy.test"),
+					resource.TestCheckResourceAttr("kibana_fleet_integration_policy.test", "input.0.stream.#", "1"),
+				),
+			},
+			{
+				Config: getFleetIntegrationPolicyConfig("1.13.0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_integration_policy.test", "integration_version", "1.13.0"),
+				),
+			},
+		},
+	})
+}
+
+func getFleetIntegrationPolicyConfig(version string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_package" "nginx" {
+    name    = "nginx"
+    version = "%s"
+    }
+
+	resource "kibana_fleet_agent_policy" "test" {
+    name      = "Web servers"
+    namespace = "production"
+    }
+
+	resource "kibana_fleet_integration_policy" "test" {
+    name                = "nginx-production"
+    agent_policy_id     = kibana_fleet_agent_policy.test.id
+    integration_name    = kibana_fleet_package.nginx.name
+    integration_version = kibana_fleet_package.nginx.version
+    input {
+        input_id = "nginx-logfile"
+        stream {
+            stream_id = "nginx.access"
+            vars      = jsonencode({ paths = ["/var/log/nginx/access.log*"] })
+        }
+    }
+    input {
+        input_id = "nginx-nginx/metrics"
+        enabled  = false
+    }
+    }
+	`, version)
+}
+
+func testCheckFleetIntegrationPolicyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No integration policy ID set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceFleetOutput() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Fleet output, where agents send their data.",
+
+		CreateContext: resourceFleetOutputCreate,
+		ReadContext:   resourceFleetOutputRead,
+		UpdateContext: resourceFleetOutputUpdate,
+		DeleteContext: resourceFleetOutputDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the output.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"type": {
+				Description:  "The output type: elasticsearch, logstash or kafka.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"elasticsearch", "logstash", "kafka"}, false),
+			},
+			"hosts": {
+				Description: "The URLs of the Elasticsearch hosts, or the host:port addresses of the Logstash or Kafka hosts.",
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_integrations": {
+				Description: "Makes this output the default output for agent integrations.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"default_monitoring": {
+				Description: "Makes this output the default output for agent monitoring.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"ca_sha256": {
+				Description: "The SHA256 fingerprint of the certificate authority used to verify the hosts.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ca_trusted_fingerprint": {
+				Description: "The SHA256 fingerprint of a certificate authority of the hosts' certificate chain to trust.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"config_yaml": {
+				Description: "Advanced YAML configuration of the output.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"proxy_id": {
+				Description: "The ID of the Fleet proxy used to reach the hosts.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ssl": {
+				Description: "The TLS configuration used by agents to connect to the hosts.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"certificate_authorities": {
+							Description: "The PEM encoded certificate authorities to trust.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"certificate": {
+							Description: "The PEM encoded client certificate.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"key": {
+							Description: "The PEM encoded client certificate key. Kibana doesn't return it, so it is never compared.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"kafka": {
+				Description: "The settings of kafka outputs.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Description: "The client ID used by agents.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"version": {
+							Description: "The Kafka protocol version.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"auth_type": {
+							Description:  "The authentication type: none, user_pass, ssl or kerberos.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "none",
+							ValidateFunc: validation.StringInSlice([]string{"none", "user_pass", "ssl", "kerberos"}, false),
+						},
+						"username": {
+							Description: "The username of user_pass authentication.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"password": {
+							Description: "The password of user_pass authentication. Kibana doesn't return it, so it is never compared.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"topic": {
+							Description: "The default topic events are published to.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"compression": {
+							Description: "The compression codec: none, gzip, snappy or lz4.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"partition": {
+							Description: "The partitioning strategy: random, round_robin or hash.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"required_acks": {
+							Description: "The acknowledgement reliability level required from brokers: 1, 0 or -1.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceFleetOutputCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	output, err := client.CreateFleetOutput(deflateFleetOutput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(output.Id)
+	return resourceFleetOutputRead(ctx, d, meta)
+}
+
+func resourceFleetOutputRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	output, err := client.ReadFleetOutput(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("name", output.Name)
+	d.Set("type", output.Type)
+	d.Set("hosts", output.Hosts)
+	d.Set("default_integrations", output.IsDefault)
+	d.Set("default_monitoring", output.IsDefaultMonitoring)
+	d.Set("ca_sha256", stringValue(output.CaSha256))
+	d.Set("ca_trusted_fingerprint", stringValue(output.CaTrustedFingerprint))
+	d.Set("config_yaml", stringValue(output.ConfigYaml))
+	d.Set("proxy_id", stringValue(output.ProxyId))
+	if output.Ssl != nil {
+		d.Set("ssl", []map[string]interface{}{{
+			"certificate_authorities": output.Ssl.CertificateAuthorities,
+			"certificate":             output.Ssl.Certificate,
+			"key":                     d.Get("ssl.0.key"),
+		}})
+	} else {
+		d.Set("ssl", nil)
+	}
+	if output.Type == "kafka" {
+		d.Set("kafka", []map[string]interface{}{{
+			"client_id":     output.ClientId,
+			"version":       output.Version,
+			"auth_type":     output.AuthType,
+			"username":      output.Username,
+			"password":      d.Get("kafka.0.password"),
+			"topic":         output.Topic,
+			"compression":   output.Compression,
+			"partition":     output.Partition,
+			"required_acks": output.RequiredAcks,
+		}})
+	}
+
+	return diags
+}
+
+func resourceFleetOutputUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	_, err := client.UpdateFleetOutput(d.Id(), deflateFleetOutput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFleetOutputRead(ctx, d, meta)
+}
+
+func resourceFleetOutputDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteFleetOutput(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateFleetOutput(d *schema.ResourceData) mykibana.FleetOutput {
+	output := mykibana.FleetOutput{}
+	output.Name = d.Get("name").(string)
+	output.Type = d.Get("type").(string)
+	output.Hosts = deflateStringList(d.Get("hosts").([]interface{}))
+	output.IsDefault = d.Get("default_integrations").(bool)
+	output.IsDefaultMonitoring = d.Get("default_monitoring").(bool)
+	output.CaSha256 = optionalString(d.Get("ca_sha256").(string))
+	output.CaTrustedFingerprint = optionalString(d.Get("ca_trusted_fingerprint").(string))
+	output.ConfigYaml = optionalString(d.Get("config_yaml").(string))
+	output.ProxyId = optionalString(d.Get("proxy_id").(string))
+	if sslList := d.Get("ssl").([]interface{}); len(sslList) > 0 && sslList[0] != nil {
+		ssl := sslList[0].(map[string]interface{})
+		output.Ssl = &mykibana.FleetOutputSsl{
+			CertificateAuthorities: deflateStringList(ssl["certificate_authorities"].([]interface{})),
+			Certificate:            ssl["certificate"].(string),
+			Key:                    ssl["key"].(string),
+		}
+	}
+	if kafkaList := d.Get("kafka").([]interface{}); len(kafkaList) > 0 && kafkaList[0] != nil {
+		kafka := kafkaList[0].(map[string]interface{})
+		output.ClientId = kafka["client_id"].(string)
+		output.Version = kafka["version"].(string)
+		output.AuthType = kafka["auth_type"].(string)
+		output.Username = kafka["username"].(string)
+		output.Password = kafka["password"].(string)
+		output.Topic = kafka["topic"].(string)
+		output.Compression = kafka["compression"].(string)
+		output.Partition = kafka["partition"].(string)
+		output.RequiredAcks = kafka["required_acks"].(int)
+	}
+	return output
+}
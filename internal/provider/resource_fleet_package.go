@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceFleetPackage() *schema.Resource {
+	return &schema.Resource{
+		Description: "Installs a Fleet integration package at a pinned version, from the package registry or from a local archive.",
+
+		CreateContext: resourceFleetPackageCreate,
+		ReadContext:   resourceFleetPackageRead,
+		UpdateContext: resourceFleetPackageUpdate,
+		DeleteContext: resourceFleetPackageDelete,
+		CustomizeDiff: resourceFleetPackageCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The package name, for example nginx.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"version": {
+				Description: "The package version to install. Changing it upgrades the package in place.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"force": {
+				Description: "Forces the installation, even when the package is already installed or is a prerelease, and forces its uninstallation.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"ignore_constraints": {
+				Description: "Ignores the Kibana version constraints of the package.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"archive_path": {
+				Description: "The path of a zip archive of the package to upload instead of installing it from the package registry. Its name and version must match the name and version attributes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"archive_sha256": {
+				Description: "The SHA256 checksum of the uploaded archive.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFleetPackageImport,
+		},
+	}
+}
+
+func resourceFleetPackageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := installFleetPackage(d, meta.(mykibana.KibanaAPI))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("name").(string))
+	return resourceFleetPackageRead(ctx, d, meta)
+}
+
+func resourceFleetPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	fleetPackage, err := client.ReadFleetPackage(d.Id(), d.Get("version").(string))
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if fleetPackage.Status != "installed" {
+		d.SetId("")
+		return diags
+	}
+	d.Set("name", d.Id())
+	if fleetPackage.InstalledVersion != "" {
+		d.Set("version", fleetPackage.InstalledVersion)
+	}
+
+	return diags
+}
+
+func resourceFleetPackageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges("version", "archive_path", "archive_sha256") {
+		err := installFleetPackage(d, meta.(mykibana.KibanaAPI))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceFleetPackageRead(ctx, d, meta)
+}
+
+func resourceFleetPackageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.UninstallFleetPackage(d.Id(), d.Get("version").(string), d.Get("force").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourceFleetPackageImport takes the package name and reads the installed
+// version.
+func resourceFleetPackageImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(mykibana.KibanaAPI)
+	fleetPackage, err := client.ReadFleetPackage(d.Id(), "")
+	if err != nil {
+		return nil, err
+	}
+	d.Set("version", fleetPackage.InstalledVersion)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceFleetPackageCustomizeDiff uploads the archive again whenever its
+// content changes.
+func resourceFleetPackageCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("archive_path") {
+		d.SetNewComputed("archive_sha256")
+		return nil
+	}
+	path := d.Get("archive_path").(string)
+	if path == "" {
+		if d.Get("archive_sha256").(string) != "" {
+			d.SetNew("archive_sha256", "")
+		}
+		return nil
+	}
+	archive, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "Reading %s failed", path)
+	}
+	if sha256Hex(archive) != d.Get("archive_sha256").(string) {
+		d.SetNew("archive_sha256", sha256Hex(archive))
+	}
+	return nil
+}
+
+func installFleetPackage(d *schema.ResourceData, client mykibana.KibanaAPI) error {
+	path := d.Get("archive_path").(string)
+	if path == "" {
+		d.Set("archive_sha256", "")
+		return client.InstallFleetPackage(d.Get("name").(string), d.Get("version").(string), d.Get("force").(bool), d.Get("ignore_constraints").(bool))
+	}
+	archive, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "Reading %s failed", path)
+	}
+	err = client.UploadFleetPackage(archive)
+	if err != nil {
+		return err
+	}
+	d.Set("archive_sha256", sha256Hex(archive))
+	return nil
+}
@@ -0,0 +1,57 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaFleetPackage(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getFleetPackageConfig("1.12.0"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckFleetPackageExists("kibana_fleet_package.test"),
+					resource.TestCheckResourceAttr("kibana_fleet_package.test", "version", "1.12.0"),
+				),
+			},
+			{
+				Config: getFleetPackageConfig("1.13.0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_package.test", "id", "nginx"),
+					resource.TestCheckResourceAttr("kibana_fleet_package.test", "version", "1.13.0"),
+				),
+			},
+		},
+	})
+}
+
+func getFleetPackageConfig(version string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_package" "test" {
+    name    = "nginx"
+    version = "%s"
+    force   = true
+    }
+	`, version)
+}
+
+func testCheckFleetPackageExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No package name set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceFleetProxy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Fleet proxy, used by agents to reach Fleet Server and outputs.",
+
+		CreateContext: resourceFleetProxyCreate,
+		ReadContext:   resourceFleetProxyRead,
+		UpdateContext: resourceFleetProxyUpdate,
+		DeleteContext: resourceFleetProxyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the proxy.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"url": {
+				Description: "The URL of the proxy.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"proxy_headers": {
+				Description: "Headers sent to the proxy on CONNECT requests.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"certificate_authorities": {
+				Description: "The PEM encoded certificate authorities used to verify the proxy.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"certificate": {
+				Description: "The PEM encoded client certificate presented to the proxy.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"certificate_key": {
+				Description: "The PEM encoded client certificate key.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceFleetProxyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	proxy, err := client.CreateFleetProxy(deflateFleetProxy(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(proxy.Id)
+	return resourceFleetProxyRead(ctx, d, meta)
+}
+
+func resourceFleetProxyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	proxy, err := client.ReadFleetProxy(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("name", proxy.Name)
+	d.Set("url", proxy.Url)
+	d.Set("proxy_headers", proxy.ProxyHeaders)
+	d.Set("certificate_authorities", proxy.CertificateAuthorities)
+	d.Set("certificate", proxy.Certificate)
+	d.Set("certificate_key", proxy.CertificateKey)
+
+	return diags
+}
+
+func resourceFleetProxyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	_, err := client.UpdateFleetProxy(d.Id(), deflateFleetProxy(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFleetProxyRead(ctx, d, meta)
+}
+
+func resourceFleetProxyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteFleetProxy(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateFleetProxy(d *schema.ResourceData) mykibana.FleetProxy {
+	proxy := mykibana.FleetProxy{}
+	proxy.Name = d.Get("name").(string)
+	proxy.Url = d.Get("url").(string)
+	proxy.ProxyHeaders = make(map[string]string)
+	for key, value := range d.Get("proxy_headers").(map[string]interface{}) {
+		proxy.ProxyHeaders[key] = value.(string)
+	}
+	proxy.CertificateAuthorities = d.Get("certificate_authorities").(string)
+	proxy.Certificate = d.Get("certificate").(string)
+	proxy.CertificateKey = d.Get("certificate_key").(string)
+	return proxy
+}
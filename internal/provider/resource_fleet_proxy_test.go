@@ -0,0 +1,58 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaFleetProxy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getFleetProxyConfig("Corporate proxy"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckFleetProxyExists("kibana_fleet_proxy.test"),
+					resource.TestCheckResourceAttr("kibana_fleet_proxy.test", "proxy_headers.X-Team", "observability"),
+				),
+			},
+			{
+				Config: getFleetProxyConfig("Datacenter proxy"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_proxy.test", "name", "Datacenter proxy"),
+				),
+			},
+		},
+	})
+}
+
+func getFleetProxyConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_proxy" "test" {
+    name = "%s"
+    url  = "http://proxy.example.com:3128"
+    proxy_headers = {
+      X-Team = "observability"
+    }
+    }
+	`, name)
+}
+
+func testCheckFleetProxyExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No proxy ID set")
+		}
+
+		return nil
+	}
+}
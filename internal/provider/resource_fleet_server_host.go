@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceFleetServerHost() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Fleet Server host, the URLs agents use to enroll and check in.",
+
+		CreateContext: resourceFleetServerHostCreate,
+		ReadContext:   resourceFleetServerHostRead,
+		UpdateContext: resourceFleetServerHostUpdate,
+		DeleteContext: resourceFleetServerHostDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The name of the Fleet Server host.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"host_urls": {
+				Description: "The URLs of the Fleet Server, for example https://fleet.example.com:8220.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"is_default": {
+				Description: "Makes this host the default Fleet Server host of agent policies.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"proxy_id": {
+				Description: "The ID of the Fleet proxy used to reach the Fleet Server.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceFleetServerHostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	host, err := client.CreateFleetServerHost(deflateFleetServerHost(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(host.Id)
+	return resourceFleetServerHostRead(ctx, d, meta)
+}
+
+func resourceFleetServerHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	host, err := client.ReadFleetServerHost(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("name", host.Name)
+	d.Set("host_urls", host.HostUrls)
+	d.Set("is_default", host.IsDefault)
+	d.Set("proxy_id", stringValue(host.ProxyId))
+
+	return diags
+}
+
+func resourceFleetServerHostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	_, err := client.UpdateFleetServerHost(d.Id(), deflateFleetServerHost(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFleetServerHostRead(ctx, d, meta)
+}
+
+func resourceFleetServerHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteFleetServerHost(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateFleetServerHost(d *schema.ResourceData) mykibana.FleetServerHost {
+	host := mykibana.FleetServerHost{}
+	host.Name = d.Get("name").(string)
+	host.HostUrls = deflateStringList(d.Get("host_urls").([]interface{}))
+	host.IsDefault = d.Get("is_default").(bool)
+	host.ProxyId = optionalString(d.Get("proxy_id").(string))
+	return host
+}
@@ -0,0 +1,77 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaFleetServerHost(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getFleetServerHostConfig("Fleet Server"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckFleetServerHostExists("kibana_fleet_server_host.test"),
+					resource.TestCheckResourceAttr("kibana_fleet_server_host.test", "host_urls.#", "1"),
+				),
+			},
+			{
+				Config: getFleetServerHostConfig("Internal Fleet Server"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_server_host.test", "name", "Internal Fleet Server"),
+				),
+			},
+			{
+				Config: getFleetServerHostConfigWithProxy("Internal Fleet Server"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_server_host.test", "proxy_id", "corporate-proxy"),
+				),
+			},
+			{
+				Config: getFleetServerHostConfig("Internal Fleet Server"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_fleet_server_host.test", "proxy_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func getFleetServerHostConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_server_host" "test" {
+    name      = "%s"
+    host_urls = ["https://fleet.example.com:8220"]
+    }
+	`, name)
+}
+
+func getFleetServerHostConfigWithProxy(name string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_server_host" "test" {
+    name      = "%s"
+    host_urls = ["https://fleet.example.com:8220"]
+    proxy_id  = "corporate-proxy"
+    }
+	`, name)
+}
+
+func testCheckFleetServerHostExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Fleet Server host ID set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceLogstashPipeline() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a pipeline of the Logstash centralized pipeline management.",
+
+		CreateContext: resourceLogstashPipelineCreate,
+		ReadContext:   resourceLogstashPipelineRead,
+		UpdateContext: resourceLogstashPipelineUpdate,
+		DeleteContext: resourceLogstashPipelineDelete,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Description:  "The ID of the pipeline, referenced by xpack.management.pipeline.id in the Logstash settings.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`), "must start with a letter or an underscore and only contain letters, digits, underscores and hyphens"),
+			},
+			"pipeline": {
+				Description:      "The pipeline configuration, usually loaded with file(). Whitespace and blank line differences are ignored.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: logstashPipelineEqual,
+			},
+			"description": {
+				Description: "The description of the pipeline.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"username": {
+				Description: "The user who last saved the pipeline.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"settings": {
+				Description: "The pipeline settings. Logstash uses its own defaults for the settings which aren't provided.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"workers": {
+							Description: "The number of workers running the filter and output stages (pipeline.workers).",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"batch_size": {
+							Description: "The maximum number of events a worker collects before running the filters and outputs (pipeline.batch.size).",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"batch_delay": {
+							Description: "The maximum time in milliseconds a worker waits for new events before dispatching an undersized batch (pipeline.batch.delay).",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"queue_type": {
+							Description:  "The queue type: memory or persisted (queue.type).",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"memory", "persisted"}, false),
+						},
+						"queue_max_bytes": {
+							Description: "The capacity of the persisted queue, for example 1gb (queue.max_bytes).",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"queue_checkpoint_writes": {
+							Description: "The number of events written to the persisted queue before a checkpoint (queue.checkpoint.writes).",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceLogstashPipelineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	pipeline := deflateLogstashPipeline(d)
+	err := client.PutLogstashPipeline(pipeline)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(pipeline.Id)
+	return resourceLogstashPipelineRead(ctx, d, meta)
+}
+
+func resourceLogstashPipelineRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	pipeline, err := client.ReadLogstashPipeline(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("pipeline_id", d.Id())
+	d.Set("pipeline", pipeline.Pipeline)
+	d.Set("description", pipeline.Description)
+	d.Set("username", pipeline.Username)
+	if pipeline.Settings != nil && *pipeline.Settings != (mykibana.LogstashPipelineSettings{}) {
+		d.Set("settings", []map[string]interface{}{{
+			"workers":                 pipeline.Settings.Workers,
+			"batch_size":              pipeline.Settings.BatchSize,
+			"batch_delay":             pipeline.Settings.BatchDelay,
+			"queue_type":              pipeline.Settings.QueueType,
+			"queue_max_bytes":         pipeline.Settings.QueueMaxBytes,
+			"queue_checkpoint_writes": pipeline.Settings.QueueCheckpointWrites,
+		}})
+	} else {
+		d.Set("settings", nil)
+	}
+
+	return diags
+}
+
+func resourceLogstashPipelineUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	err := client.PutLogstashPipeline(deflateLogstashPipeline(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceLogstashPipelineRead(ctx, d, meta)
+}
+
+func resourceLogstashPipelineDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteLogstashPipeline(d.Id())
+	if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateLogstashPipeline(d *schema.ResourceData) mykibana.LogstashPipeline {
+	pipeline := mykibana.LogstashPipeline{}
+	pipeline.Id = d.Get("pipeline_id").(string)
+	pipeline.Pipeline = d.Get("pipeline").(string)
+	pipeline.Description = d.Get("description").(string)
+	if settingsList := d.Get("settings").([]interface{}); len(settingsList) > 0 && settingsList[0] != nil {
+		settings := settingsList[0].(map[string]interface{})
+		pipeline.Settings = &mykibana.LogstashPipelineSettings{
+			Workers:               settings["workers"].(int),
+			BatchSize:             settings["batch_size"].(int),
+			BatchDelay:            settings["batch_delay"].(int),
+			QueueType:             settings["queue_type"].(string),
+			QueueMaxBytes:         settings["queue_max_bytes"].(string),
+			QueueCheckpointWrites: settings["queue_checkpoint_writes"].(int),
+		}
+	}
+	return pipeline
+}
+
+// logstashPipelineEqual ignores line endings, trailing whitespace and blank
+// lines, which the Kibana editor changes when a pipeline is saved.
+func logstashPipelineEqual(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return normalizeLogstashPipeline(oldValue) == normalizeLogstashPipeline(newValue)
+}
+
+func normalizeLogstashPipeline(pipeline string) string {
+	lines := strings.Split(strings.ReplaceAll(pipeline, "\r\n", "\n"), "\n")
+	normalized := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		normalized = append(normalized, line)
+	}
+	return strings.Join(normalized, "\n")
+}
@@ -0,0 +1,65 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaLogstashPipeline(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getLogstashPipelineConfig("Beats to Elasticsearch", 2),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckLogstashPipelineExists("kibana_logstash_pipeline.test"),
+					resource.TestCheckResourceAttr("kibana_logstash_pipeline.test", "settings.0.workers", "2"),
+				),
+			},
+			{
+				Config: getLogstashPipelineConfig("Beats events to Elasticsearch", 4),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_logstash_pipeline.test", "description", "Beats events to Elasticsearch"),
+					resource.TestCheckResourceAttr("kibana_logstash_pipeline.test", "settings.0.workers", "4"),
+				),
+			},
+		},
+	})
+}
+
+func getLogstashPipelineConfig(description string, workers int) string {
+	return fmt.Sprintf(`
+	resource "kibana_logstash_pipeline" "test" {
+    pipeline_id = "beats"
+    description = "%s"
+    pipeline    = <<-EOT
+      input { beats { port => 5044 } }
+      output { elasticsearch { hosts => ["http://localhost:9200"] } }
+    EOT
+    settings {
+      workers    = %d
+      batch_size = 250
+      queue_type = "persisted"
+    }
+    }
+	`, description, workers)
+}
+
+func testCheckLogstashPipelineExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No pipeline ID set")
+		}
+
+		return nil
+	}
+}
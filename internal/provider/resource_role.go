@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceRole() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Kibana role, granting Elasticsearch and Kibana privileges.",
+
+		CreateContext: resourceRoleCreate,
+		ReadContext:   resourceRoleRead,
+		UpdateContext: resourceRoleUpdate,
+		DeleteContext: resourceRoleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "The role name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"metadata": {
+				Description:      "Optional meta-data as JSON. Within the metadata object, keys that begin with _ are reserved for system usage.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: rawJsonEqual,
+			},
+			"elasticsearch": {
+				Description: "Elasticsearch cluster and index privileges.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster": {
+							Description: "Cluster privileges that define the cluster level actions that users can perform.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"indices": {
+							Description: "Indices privileges that define the index level actions that users can perform.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"names": {
+										Description: "The data streams, indices, and aliases to which the permissions apply. Supports wildcards (*).",
+										Type:        schema.TypeList,
+										Required:    true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"privileges": {
+										Description: "The index level privileges that the role members have for the data streams and indices.",
+										Type:        schema.TypeSet,
+										Required:    true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"query": {
+										Description:      "A search query that defines the documents the role members have read access to.",
+										Type:             schema.TypeString,
+										Optional:         true,
+										DiffSuppressFunc: rawJsonEqual,
+									},
+									"field_security": {
+										Description: "The document fields that the role members have read access to.",
+										Type:        schema.TypeList,
+										Optional:    true,
+										MaxItems:    1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"grant": {
+													Description: "The fields to grant access to. Supports wildcards (*).",
+													Type:        schema.TypeList,
+													Optional:    true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+												},
+												"except": {
+													Description: "The fields to deny access to, among the granted fields.",
+													Type:        schema.TypeList,
+													Optional:    true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+												},
+											},
+										},
+									},
+									"allow_restricted_indices": {
+										Description: "Allows the names to cover restricted indices such as .security.",
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+								},
+							},
+						},
+						"run_as": {
+							Description: "The users that the role members can impersonate.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"kibana": {
+				Description: "Kibana privileges, each block granting either base or feature privileges on a set of spaces.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"base": {
+							Description: "Base privileges granted on every feature: all or read. Conflicts with feature.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"feature": {
+							Description: "Feature privileges, such as discover: all.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "The feature identifier, for example discover, dashboard or fleet.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"privileges": {
+										Description: "The privileges granted on the feature, for example all, read or minimal_read.",
+										Type:        schema.TypeSet,
+										Required:    true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"spaces": {
+							Description: "The spaces the privileges apply to. Use * for all spaces.",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	role := deflateRole(d)
+	err := client.PutRole(role)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(role.Name)
+	return resourceRoleRead(ctx, d, meta)
+}
+
+func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	role, err := client.ReadRole(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("name", d.Id())
+	d.Set("metadata", flattenRawJson(role.Metadata))
+	d.Set("elasticsearch", flattenRoleElasticsearch(role.Elasticsearch))
+	d.Set("kibana", flattenRoleKibana(role.Kibana))
+
+	return diags
+}
+
+func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	err := client.PutRole(deflateRole(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceRoleRead(ctx, d, meta)
+}
+
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteRole(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func deflateRole(d *schema.ResourceData) mykibana.Role {
+	role := mykibana.Role{}
+	role.Name = d.Get("name").(string)
+	if metadata := d.Get("metadata").(string); metadata != "" {
+		role.Metadata = json.RawMessage([]byte(metadata))
+	}
+	role.Elasticsearch = mykibana.RoleElasticsearch{Cluster: []string{}, Indices: []mykibana.RoleIndex{}, RunAs: []string{}}
+	if elasticsearchList := d.Get("elasticsearch").([]interface{}); len(elasticsearchList) > 0 && elasticsearchList[0] != nil {
+		elasticsearch := elasticsearchList[0].(map[string]interface{})
+		role.Elasticsearch.Cluster = deflateStringSet(elasticsearch["cluster"].(*schema.Set))
+		role.Elasticsearch.RunAs = deflateStringSet(elasticsearch["run_as"].(*schema.Set))
+		for _, flatIndex := range elasticsearch["indices"].([]interface{}) {
+			role.Elasticsearch.Indices = append(role.Elasticsearch.Indices, deflateRoleIndex(flatIndex.(map[string]interface{})))
+		}
+	}
+	role.Kibana = []mykibana.RoleKibana{}
+	for _, flatKibana := range d.Get("kibana").([]interface{}) {
+		kibana := flatKibana.(map[string]interface{})
+		privileges := mykibana.RoleKibana{}
+		privileges.Base = deflateStringSet(kibana["base"].(*schema.Set))
+		privileges.Feature = make(map[string][]string)
+		for _, flatFeature := range kibana["feature"].(*schema.Set).List() {
+			feature := flatFeature.(map[string]interface{})
+			privileges.Feature[feature["name"].(string)] = deflateStringSet(feature["privileges"].(*schema.Set))
+		}
+		privileges.Spaces = deflateStringList(kibana["spaces"].([]interface{}))
+		role.Kibana = append(role.Kibana, privileges)
+	}
+	return role
+}
+
+func deflateRoleIndex(flatIndex map[string]interface{}) mykibana.RoleIndex {
+	index := mykibana.RoleIndex{}
+	index.Names = deflateStringList(flatIndex["names"].([]interface{}))
+	index.Privileges = deflateStringSet(flatIndex["privileges"].(*schema.Set))
+	index.Query = flatIndex["query"].(string)
+	index.AllowRestrictedIndices = flatIndex["allow_restricted_indices"].(bool)
+	if fieldSecurityList := flatIndex["field_security"].([]interface{}); len(fieldSecurityList) > 0 && fieldSecurityList[0] != nil {
+		fieldSecurity := fieldSecurityList[0].(map[string]interface{})
+		index.FieldSecurity = &mykibana.RoleFieldSecurity{
+			Grant:  deflateStringList(fieldSecurity["grant"].([]interface{})),
+			Except: deflateStringList(fieldSecurity["except"].([]interface{})),
+		}
+	}
+	return index
+}
+
+func flattenRoleElasticsearch(elasticsearch mykibana.RoleElasticsearch) []map[string]interface{} {
+	indices := make([]map[string]interface{}, 0, len(elasticsearch.Indices))
+	for _, i := range elasticsearch.Indices {
+		index := make(map[string]interface{})
+		index["names"] = i.Names
+		index["privileges"] = i.Privileges
+		index["query"] = i.Query
+		index["allow_restricted_indices"] = i.AllowRestrictedIndices
+		if i.FieldSecurity != nil {
+			index["field_security"] = []map[string]interface{}{{
+				"grant":  i.FieldSecurity.Grant,
+				"except": i.FieldSecurity.Except,
+			}}
+		}
+		indices = append(indices, index)
+	}
+	return []map[string]interface{}{{
+		"cluster": elasticsearch.Cluster,
+		"indices": indices,
+		"run_as":  elasticsearch.RunAs,
+	}}
+}
+
+func flattenRoleKibana(kibana []mykibana.RoleKibana) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(kibana))
+	for _, k := range kibana {
+		privileges := make(map[string]interface{})
+		features := make([]interface{}, 0, len(k.Feature))
+		for name, featurePrivileges := range k.Feature {
+			features = append(features, map[string]interface{}{
+				"name":       name,
+				"privileges": stringSet(featurePrivileges),
+			})
+		}
+		privileges["base"] = k.Base
+		privileges["feature"] = features
+		privileges["spaces"] = k.Spaces
+		res = append(res, privileges)
+	}
+	return res
+}
+
+func deflateStringList(list []interface{}) []string {
+	res := make([]string, 0, len(list))
+	for _, item := range list {
+		res = append(res, item.(string))
+	}
+	return res
+}
+
+func deflateStringSet(set *schema.Set) []string {
+	return deflateStringList(set.List())
+}
+
+func stringSet(list []string) *schema.Set {
+	items := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		items = append(items, item)
+	}
+	return schema.NewSet(schema.HashString, items)
+}
@@ -0,0 +1,78 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getRoleConfig("read"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRoleExists("kibana_role.test"),
+					resource.TestCheckResourceAttr("kibana_role.test", "elasticsearch.0.indices.#", "1"),
+					resource.TestCheckResourceAttr("kibana_role.test", "kibana.#", "1"),
+				),
+			},
+			{
+				Config: getRoleConfig("all"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_role.test", "kibana.0.feature.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func getRoleConfig(dashboardPrivilege string) string {
+	return fmt.Sprintf(`
+	resource "kibana_role" "test" {
+    name     = "observability_reader"
+    metadata = jsonencode({ team = "sre" })
+    elasticsearch {
+        cluster = ["monitor"]
+        indices {
+            names      = ["logs-*", "metrics-*"]
+            privileges = ["read", "view_index_metadata"]
+            field_security {
+                grant  = ["*"]
+                except = ["user.email"]
+            }
+        }
+    }
+    kibana {
+        spaces = ["observability"]
+        feature {
+            name       = "discover"
+            privileges = ["read"]
+        }
+        feature {
+            name       = "dashboard"
+            privileges = ["%s"]
+        }
+    }
+    }
+	`, dashboardPrivilege)
+}
+
+func testCheckRoleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No role name set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceSavedObject() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a single Kibana saved object such as a saved search, a tag or a map.",
+
+		CreateContext: resourceSavedObjectCreate,
+		ReadContext:   resourceSavedObjectRead,
+		UpdateContext: resourceSavedObjectUpdate,
+		DeleteContext: resourceSavedObjectDelete,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description: "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Description: "The saved object type, for example search, tag or map.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"object_id": {
+				Description: "The saved object ID. Generated by Kibana when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"attributes": {
+				Description:      "The saved object attributes as JSON. Only the attributes set in the configuration are compared with the ones of Kibana, which adds defaults to some object types.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: savedObjectAttributesEqual,
+			},
+			"references": {
+				Description: "The saved objects this object refers to.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "The referenced saved object type.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"id": {
+							Description: "The referenced saved object ID.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"name": {
+							Description: "The name of the reference, as used in the attributes.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"namespaces": {
+				Description: "The spaces the saved object is shared with.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSavedObjectImport,
+		},
+	}
+}
+
+func resourceSavedObjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	object, err := deflateSavedObject(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	object.Id = d.Get("object_id").(string)
+	for _, namespace := range d.Get("namespaces").([]interface{}) {
+		object.Namespaces = append(object.Namespaces, namespace.(string))
+	}
+	created, err := client.CreateSavedObject(d.Get("space_id").(string), object)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", created.Type, created.Id))
+	return resourceSavedObjectRead(ctx, d, meta)
+}
+
+func resourceSavedObjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	objectType, objectId, err := parseSavedObjectId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	object, err := client.ReadSavedObject(d.Get("space_id").(string), objectType, objectId)
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	references := make([]map[string]interface{}, 0, len(object.References))
+	for _, r := range object.References {
+		reference := make(map[string]interface{})
+		reference["type"] = r.Type
+		reference["id"] = r.Id
+		reference["name"] = r.Name
+		references = append(references, reference)
+	}
+	d.Set("type", object.Type)
+	d.Set("object_id", object.Id)
+	attributes, err := keepSavedObjectAttributes(object.Attributes, d.Get("attributes").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("attributes", string(attributes))
+	d.Set("references", references)
+	d.Set("namespaces", object.Namespaces)
+
+	return diags
+}
+
+func resourceSavedObjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	object, err := deflateSavedObject(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	object.Id = d.Get("object_id").(string)
+	_, err = client.UpdateSavedObject(d.Get("space_id").(string), object)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceSavedObjectRead(ctx, d, meta)
+}
+
+func resourceSavedObjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	objectType, objectId, err := parseSavedObjectId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = client.DeleteSavedObject(d.Get("space_id").(string), objectType, objectId)
+	if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourceSavedObjectImport accepts import IDs in the form
+// [<space_id>/]<type>/<object_id>.
+func resourceSavedObjectImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	switch len(parts) {
+	case 2:
+		d.SetId(fmt.Sprintf("%s/%s", parts[0], parts[1]))
+	case 3:
+		d.Set("space_id", parts[0])
+		d.SetId(fmt.Sprintf("%s/%s", parts[1], parts[2]))
+	default:
+		return nil, fmt.Errorf("Invalid import ID %s, expected [<space_id>/]<type>/<object_id>", d.Id())
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func deflateSavedObject(d *schema.ResourceData) (mykibana.SavedObject, error) {
+	object := mykibana.SavedObject{}
+	object.Type = d.Get("type").(string)
+	attributes := json.RawMessage(d.Get("attributes").(string))
+	if !json.Valid(attributes) {
+		return object, fmt.Errorf("Invalid attributes, expected a JSON object")
+	}
+	object.Attributes = attributes
+	object.References = []mykibana.SavedObjectReference{}
+	for _, reference := range d.Get("references").([]interface{}) {
+		flatReference := reference.(map[string]interface{})
+		object.References = append(object.References, mykibana.SavedObjectReference{
+			Type: flatReference["type"].(string),
+			Id:   flatReference["id"].(string),
+			Name: flatReference["name"].(string),
+		})
+	}
+	return object, nil
+}
+
+func parseSavedObjectId(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("Invalid saved object ID %s, expected <type>/<object_id>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// keepSavedObjectAttributes returns the attributes read from Kibana restricted
+// to the keys of the attributes in the state, so that the defaults added by
+// Kibana don't show in the plans. Every attribute is kept when importing.
+func keepSavedObjectAttributes(attributes json.RawMessage, stateAttributes string) (json.RawMessage, error) {
+	var state map[string]json.RawMessage
+	if stateAttributes == "" || json.Unmarshal([]byte(stateAttributes), &state) != nil {
+		return attributes, nil
+	}
+	var read map[string]json.RawMessage
+	if err := json.Unmarshal(attributes, &read); err != nil {
+		return nil, errors.Wrapf(err, "Invalid attributes")
+	}
+	kept := make(map[string]json.RawMessage, len(state))
+	for key := range state {
+		if value, ok := read[key]; ok {
+			kept[key] = value
+		}
+	}
+	return json.Marshal(kept)
+}
+
+// savedObjectAttributesEqual only compares the attributes of the
+// configuration, like the drift detection of kibana_saved_objects.
+func savedObjectAttributesEqual(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return savedObjectAttributesMatch(json.RawMessage(newValue), json.RawMessage(oldValue))
+}
@@ -0,0 +1,63 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaSavedObject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getSavedObjectConfig("#D36086"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSavedObjectExists("kibana_saved_object.test"),
+					resource.TestCheckResourceAttr("kibana_saved_object.test", "object_id", "tag-production"),
+					resource.TestCheckResourceAttr("kibana_saved_object.test", "references.#", "0"),
+				),
+			},
+			{
+				Config: getSavedObjectConfig("#54B399"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_saved_object.test", "id", "tag/tag-production"),
+				),
+			},
+		},
+	})
+}
+
+func getSavedObjectConfig(color string) string {
+	return fmt.Sprintf(`
+	resource "kibana_saved_object" "test" {
+    type       = "tag"
+    object_id  = "tag-production"
+    attributes = jsonencode(
+        {
+            name        = "production"
+            description = "Production resources"
+            color       = "%s"
+        }
+    )
+    }
+	`, color)
+}
+
+func testCheckSavedObjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No saved object ID set")
+		}
+
+		return nil
+	}
+}
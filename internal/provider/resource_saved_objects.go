@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceSavedObjects() *schema.Resource {
+	return &schema.Resource{
+		Description: "Imports a bundle of saved objects (dashboards, visualizations, Lens panels...) exported from Kibana as NDJSON.",
+
+		CreateContext: resourceSavedObjectsCreate,
+		ReadContext:   resourceSavedObjectsRead,
+		UpdateContext: resourceSavedObjectsUpdate,
+		DeleteContext: resourceSavedObjectsDelete,
+		CustomizeDiff: resourceSavedObjectsCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description: "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"ndjson": {
+				Description:  "The NDJSON export to import. Conflicts with ndjson_file.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"ndjson", "ndjson_file"},
+			},
+			"ndjson_file": {
+				Description:  "The path of an NDJSON export file to import. Conflicts with ndjson.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"ndjson", "ndjson_file"},
+			},
+			"overwrite": {
+				Description: "Overwrites saved objects when they already exist. Ignored when create_new_copies is set.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"create_new_copies": {
+				Description: "Creates copies of the saved objects with regenerated IDs instead of reusing the IDs of the export.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"ndjson_sha256": {
+				Description: "The SHA256 checksum of the imported NDJSON. Cleared when the saved objects drift from the export.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"objects": {
+				Description: "The saved objects managed by this resource.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "The saved object type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"id": {
+							Description: "The saved object ID in Kibana.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"source_id": {
+							Description: "The saved object ID in the NDJSON export.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSavedObjectsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	ndjson, err := loadSavedObjectsNdjson(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	results, err := client.ImportSavedObjects(d.Get("space_id").(string), ndjson, d.Get("overwrite").(bool), d.Get("create_new_copies").(bool))
+	d.SetId(resource.UniqueId())
+	d.Set("objects", flattenSavedObjectImportResults(results))
+	if err != nil {
+		// Partially imported objects are kept in the state so that they get
+		// deleted with the tainted resource.
+		if len(results) == 0 {
+			d.SetId("")
+		}
+		return diag.FromErr(err)
+	}
+	d.Set("ndjson_sha256", sha256Hex(ndjson))
+	return resourceSavedObjectsRead(ctx, d, meta)
+}
+
+func resourceSavedObjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	objects := deflateSavedObjectIds(d)
+	exported, err := client.ExportSavedObjects(d.Get("space_id").(string), objects)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Objects deleted in Kibana clear the checksum, which plans a new import,
+	// even when none of them is left.
+	if len(exported) != len(objects) {
+		d.Set("ndjson_sha256", "")
+		return diags
+	}
+	ndjson, err := loadSavedObjectsNdjson(d)
+	if err != nil {
+		// The export can't be compared with a source that is gone, the next
+		// plan reports the error.
+		return diags
+	}
+	source, err := mykibana.ParseSavedObjectsNdjson(bytes.NewReader(ndjson))
+	if err != nil {
+		return diags
+	}
+	if !savedObjectsMatchSource(d, source, exported) {
+		d.Set("ndjson_sha256", "")
+	}
+	return diags
+}
+
+func resourceSavedObjectsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	spaceId := d.Get("space_id").(string)
+	ndjson, err := loadSavedObjectsNdjson(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	previous := deflateSavedObjectIds(d)
+	results, err := client.ImportSavedObjects(spaceId, ndjson, d.Get("overwrite").(bool), d.Get("create_new_copies").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("objects", flattenSavedObjectImportResults(results))
+	d.Set("ndjson_sha256", sha256Hex(ndjson))
+	// Delete the objects which were removed from the export.
+	for _, object := range previous {
+		kept := false
+		for _, result := range results {
+			if result.Type == object.Type && savedObjectImportResultId(result) == object.Id {
+				kept = true
+				break
+			}
+		}
+		if kept {
+			continue
+		}
+		err = client.DeleteSavedObject(spaceId, object.Type, object.Id)
+		if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceSavedObjectsRead(ctx, d, meta)
+}
+
+func resourceSavedObjectsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	for _, object := range deflateSavedObjectIds(d) {
+		err := client.DeleteSavedObject(d.Get("space_id").(string), object.Type, object.Id)
+		if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
+
+// resourceSavedObjectsCustomizeDiff plans a new import whenever the NDJSON
+// content changes, including when only the content of ndjson_file does.
+func resourceSavedObjectsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("ndjson") || !d.NewValueKnown("ndjson_file") {
+		d.SetNewComputed("ndjson_sha256")
+		d.SetNewComputed("objects")
+		return nil
+	}
+	ndjson := []byte(d.Get("ndjson").(string))
+	if path := d.Get("ndjson_file").(string); path != "" {
+		var err error
+		ndjson, err = ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "Reading %s failed", path)
+		}
+	}
+	if sha256Hex(ndjson) != d.Get("ndjson_sha256").(string) {
+		d.SetNew("ndjson_sha256", sha256Hex(ndjson))
+		d.SetNewComputed("objects")
+	}
+	return nil
+}
+
+func loadSavedObjectsNdjson(d *schema.ResourceData) ([]byte, error) {
+	if path := d.Get("ndjson_file").(string); path != "" {
+		ndjson, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Reading %s failed", path)
+		}
+		return ndjson, nil
+	}
+	return []byte(d.Get("ndjson").(string)), nil
+}
+
+func deflateSavedObjectIds(d *schema.ResourceData) []mykibana.SavedObjectId {
+	objects := []mykibana.SavedObjectId{}
+	for _, object := range d.Get("objects").([]interface{}) {
+		flatObject := object.(map[string]interface{})
+		objects = append(objects, mykibana.SavedObjectId{
+			Type: flatObject["type"].(string),
+			Id:   flatObject["id"].(string),
+		})
+	}
+	return objects
+}
+
+func flattenSavedObjectImportResults(results []mykibana.SavedObjectImportResult) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		object := make(map[string]interface{})
+		object["type"] = result.Type
+		object["id"] = savedObjectImportResultId(result)
+		object["source_id"] = result.Id
+		res = append(res, object)
+	}
+	return res
+}
+
+func savedObjectImportResultId(result mykibana.SavedObjectImportResult) string {
+	if result.DestinationId != "" {
+		return result.DestinationId
+	}
+	return result.Id
+}
+
+// savedObjectsMatchSource compares the attributes and references of the
+// exported objects with the ones of the NDJSON source. Only the attributes
+// present in the source are compared since Kibana adds defaults when migrating
+// imported objects.
+func savedObjectsMatchSource(d *schema.ResourceData, source []mykibana.SavedObject, exported []mykibana.SavedObject) bool {
+	sourceIds := make(map[mykibana.SavedObjectId]string)
+	importedIds := make(map[mykibana.SavedObjectId]string)
+	for _, object := range d.Get("objects").([]interface{}) {
+		flatObject := object.(map[string]interface{})
+		objectType := flatObject["type"].(string)
+		sourceIds[mykibana.SavedObjectId{Type: objectType, Id: flatObject["id"].(string)}] = flatObject["source_id"].(string)
+		importedIds[mykibana.SavedObjectId{Type: objectType, Id: flatObject["source_id"].(string)}] = flatObject["id"].(string)
+	}
+	sourceObjects := make(map[mykibana.SavedObjectId]mykibana.SavedObject)
+	for _, object := range source {
+		sourceObjects[mykibana.SavedObjectId{Type: object.Type, Id: object.Id}] = object
+	}
+	for _, object := range exported {
+		sourceId, ok := sourceIds[mykibana.SavedObjectId{Type: object.Type, Id: object.Id}]
+		if !ok {
+			continue
+		}
+		sourceObject, ok := sourceObjects[mykibana.SavedObjectId{Type: object.Type, Id: sourceId}]
+		if !ok {
+			return false
+		}
+		if !savedObjectAttributesMatch(sourceObject.Attributes, object.Attributes) {
+			return false
+		}
+		if !savedObjectReferencesMatch(sourceObject.References, object.References, importedIds) {
+			return false
+		}
+	}
+	return true
+}
+
+// savedObjectReferencesMatch checks that the exported object still has the
+// references of the source, pointing to the IDs the referenced objects were
+// imported with when they are part of the export. Like for attributes, the
+// references Kibana adds when migrating imported objects are ignored.
+func savedObjectReferencesMatch(sourceReferences, exportedReferences []mykibana.SavedObjectReference, importedIds map[mykibana.SavedObjectId]string) bool {
+	exported := make(map[mykibana.SavedObjectReference]int, len(exportedReferences))
+	for _, reference := range exportedReferences {
+		exported[reference]++
+	}
+	for _, reference := range sourceReferences {
+		if id, ok := importedIds[mykibana.SavedObjectId{Type: reference.Type, Id: reference.Id}]; ok {
+			reference.Id = id
+		}
+		if exported[reference] == 0 {
+			return false
+		}
+		exported[reference]--
+	}
+	return true
+}
+
+func savedObjectAttributesMatch(sourceAttributes, exportedAttributes json.RawMessage) bool {
+	var source, exported map[string]interface{}
+	if err := json.Unmarshal(sourceAttributes, &source); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(exportedAttributes, &exported); err != nil {
+		return false
+	}
+	for key, value := range source {
+		if !reflect.DeepEqual(normalizeSavedObjectValue(value), normalizeSavedObjectValue(exported[key])) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeSavedObjectValue decodes the JSON documents that Kibana stores as
+// strings in saved object attributes (visState, panelsJSON,
+// searchSourceJSON...) so that formatting differences are ignored.
+func normalizeSavedObjectValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+				return normalizeSavedObjectValue(decoded)
+			}
+		}
+		return v
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeSavedObjectValue(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			normalized = append(normalized, normalizeSavedObjectValue(item))
+		}
+		return normalized
+	}
+	return value
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func TestKibanaSavedObjects(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getSavedObjectsConfig("Overview"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSavedObjectsExist("kibana_saved_objects.test"),
+					resource.TestCheckResourceAttr("kibana_saved_objects.test", "objects.#", "2"),
+					resource.TestCheckResourceAttr("kibana_saved_objects.test", "objects.0.id", "7adfa750-4c81-11e8-b3d7-01146121b73d"),
+				),
+			},
+			{
+				Config: getSavedObjectsConfig("Overview v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_saved_objects.test", "objects.#", "2"),
+				),
+			},
+			{
+				PreConfig: func() {
+					client := provider.Meta().(mykibana.KibanaAPI)
+					client.DeleteSavedObject("", "dashboard", "7adfa750-4c81-11e8-b3d7-01146121b73d")
+					client.DeleteSavedObject("", "visualization", "80b956f0-b2cd-11e8-ad8e-85441f0c2e5c")
+				},
+				Config: getSavedObjectsConfig("Overview v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSavedObjectsReferences("dashboard", "7adfa750-4c81-11e8-b3d7-01146121b73d", 1),
+					testCheckSavedObjectsReferences("visualization", "80b956f0-b2cd-11e8-ad8e-85441f0c2e5c", 0),
+				),
+			},
+			{
+				PreConfig: func() {
+					client := provider.Meta().(mykibana.KibanaAPI)
+					dashboard, _ := client.ReadSavedObject("", "dashboard", "7adfa750-4c81-11e8-b3d7-01146121b73d")
+					dashboard.References = nil
+					client.UpdateSavedObject("", dashboard)
+				},
+				Config: getSavedObjectsConfig("Overview v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSavedObjectsReferences("dashboard", "7adfa750-4c81-11e8-b3d7-01146121b73d", 1),
+				),
+			},
+		},
+	})
+}
+
+func getSavedObjectsConfig(title string) string {
+	return fmt.Sprintf(`
+	resource "kibana_saved_objects" "test" {
+    ndjson = join("\n", [
+        jsonencode({
+            type       = "dashboard"
+            id         = "7adfa750-4c81-11e8-b3d7-01146121b73d"
+            attributes = {
+                title      = "%s"
+                panelsJSON = jsonencode([{ panelIndex = "1", panelRefName = "panel_0" }])
+            }
+            references = [{ type = "visualization", id = "80b956f0-b2cd-11e8-ad8e-85441f0c2e5c", name = "panel_0" }]
+        }),
+        jsonencode({
+            type       = "visualization"
+            id         = "80b956f0-b2cd-11e8-ad8e-85441f0c2e5c"
+            attributes = {
+                title    = "Requests"
+                visState = jsonencode({ type = "metric" })
+            }
+        }),
+    ])
+    }
+	`, title)
+}
+
+func testCheckSavedObjectsReferences(objectType, id string, references int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		object, err := provider.Meta().(mykibana.KibanaAPI).ReadSavedObject("", objectType, id)
+		if err != nil {
+			return err
+		}
+		if len(object.References) != references {
+			return fmt.Errorf("Expected %d references on %s %s, got %d", references, objectType, id, len(object.References))
+		}
+		return nil
+	}
+}
+
+func testCheckSavedObjectsExist(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No saved objects ID set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,733 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+// sloIndicatorBlocks maps the indicator blocks to the indicator types.
+var sloIndicatorBlocks = map[string]string{
+	"kql_custom_indicator":       mykibana.SloIndicatorKqlCustom,
+	"metric_custom_indicator":    mykibana.SloIndicatorMetricCustom,
+	"histogram_custom_indicator": mykibana.SloIndicatorHistogramCustom,
+	"apm_latency_indicator":      mykibana.SloIndicatorApmLatency,
+	"apm_availability_indicator": mykibana.SloIndicatorApmAvailability,
+	"timeslice_metric_indicator": mykibana.SloIndicatorTimesliceMetric,
+}
+
+func resourceSlo() *schema.Resource {
+	indicatorNames := make([]string, 0, len(sloIndicatorBlocks))
+	for name := range sloIndicatorBlocks {
+		indicatorNames = append(indicatorNames, name)
+	}
+	// sourceSchema returns the index settings shared by the custom indicators
+	// merged with the indicator specific ones.
+	sourceSchema := func(indicatorSchema map[string]*schema.Schema) map[string]*schema.Schema {
+		indicatorSchema["index"] = &schema.Schema{
+			Description: "The index pattern of the source documents.",
+			Type:        schema.TypeString,
+			Required:    true,
+		}
+		indicatorSchema["data_view_id"] = &schema.Schema{
+			Description: "The ID of the data view of the source documents, used to display their fields in Kibana.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+		indicatorSchema["filter"] = &schema.Schema{
+			Description: "A KQL query selecting the source documents.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+		indicatorSchema["timestamp_field"] = &schema.Schema{
+			Description: "The timestamp field of the source documents.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "@timestamp",
+		}
+		return indicatorSchema
+	}
+	metricsSchema := func(aggregations []string) *schema.Schema {
+		return &schema.Schema{
+			Description: "The metrics used in the equation.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "The name of the metric in the equation, A to Z.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"aggregation": {
+						Description:  "The aggregation of the metric.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(aggregations, false),
+					},
+					"field": {
+						Description: "The aggregated field. Not used by the doc_count aggregation.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"percentile": {
+						Description: "The percentile of the percentile aggregation.",
+						Type:        schema.TypeFloat,
+						Optional:    true,
+					},
+					"filter": {
+						Description: "A KQL query selecting the aggregated documents.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		}
+	}
+	metricSideSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metric": metricsSchema([]string{"sum", "doc_count"}),
+					"equation": {
+						Description: "The equation combining the metrics, for example A + B.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+				},
+			},
+		}
+	}
+	histogramSideSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"aggregation": {
+						Description:  "The aggregation of the histogram: value_count or range.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"value_count", "range"}, false),
+					},
+					"field": {
+						Description: "The histogram field.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"from": {
+						Description: "The lower bound of the range aggregation.",
+						Type:        schema.TypeFloat,
+						Optional:    true,
+					},
+					"to": {
+						Description: "The upper bound of the range aggregation.",
+						Type:        schema.TypeFloat,
+						Optional:    true,
+					},
+					"filter": {
+						Description: "A KQL query selecting the aggregated documents.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		}
+	}
+	apmSchema := func(threshold bool) map[string]*schema.Schema {
+		apm := map[string]*schema.Schema{
+			"service": {
+				Description: "The APM service name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"environment": {
+				Description: "The APM service environment, * for all environments.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"transaction_type": {
+				Description: "The transaction type, * for all types.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"transaction_name": {
+				Description: "The transaction name, * for all transactions.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"index": {
+				Description: "The APM indices.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"filter": {
+				Description: "A KQL query selecting the transactions.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		}
+		if threshold {
+			apm["threshold"] = &schema.Schema{
+				Description: "The latency threshold in milliseconds.",
+				Type:        schema.TypeFloat,
+				Required:    true,
+			}
+		}
+		return apm
+	}
+	indicatorSchema := func(description string, indicatorSchema map[string]*schema.Schema) *schema.Schema {
+		return &schema.Schema{
+			Description:  description,
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: indicatorNames,
+			Elem: &schema.Resource{
+				Schema: indicatorSchema,
+			},
+		}
+	}
+	return &schema.Resource{
+		Description: "Manages a service level objective (SLO) of the Observability app. Exactly one indicator block sets the SLI.",
+
+		CreateContext: resourceSloCreate,
+		ReadContext:   resourceSloRead,
+		UpdateContext: resourceSloUpdate,
+		DeleteContext: resourceSloDelete,
+		CustomizeDiff: resourceSloCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description: "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"slo_id": {
+				Description: "The ID of the SLO, generated by Kibana when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "The name of the SLO.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "The description of the SLO.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"kql_custom_indicator": indicatorSchema("Counts the good and total events matching KQL queries.", sourceSchema(map[string]*schema.Schema{
+				"good": {
+					Description: "A KQL query selecting the good events.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"total": {
+					Description: "A KQL query selecting all the events.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			})),
+			"metric_custom_indicator": indicatorSchema("Computes the good and total events from metric aggregations.", sourceSchema(map[string]*schema.Schema{
+				"good":  metricSideSchema("The equation computing the good events."),
+				"total": metricSideSchema("The equation computing all the events."),
+			})),
+			"histogram_custom_indicator": indicatorSchema("Computes the good and total events from histogram fields.", sourceSchema(map[string]*schema.Schema{
+				"good":  histogramSideSchema("The aggregation computing the good events."),
+				"total": histogramSideSchema("The aggregation computing all the events."),
+			})),
+			"apm_latency_indicator":      indicatorSchema("Counts the APM transactions faster than a threshold.", apmSchema(true)),
+			"apm_availability_indicator": indicatorSchema("Counts the successful APM transactions.", apmSchema(false)),
+			"timeslice_metric_indicator": indicatorSchema("Compares a metric with a threshold on each time slice, for the timeslices budgeting method.", sourceSchema(map[string]*schema.Schema{
+				"metric": metricsSchema([]string{"avg", "max", "min", "sum", "cardinality", "last_value", "std_deviation", "doc_count", "percentile"}),
+				"equation": {
+					Description: "The equation combining the metrics, for example A / B.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"comparator": {
+					Description:  "Compares the equation result with the threshold: GT, GTE, LT or LTE.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"GT", "GTE", "LT", "LTE"}, false),
+				},
+				"threshold": {
+					Description: "The threshold a good time slice meets.",
+					Type:        schema.TypeFloat,
+					Required:    true,
+				},
+			})),
+			"time_window": {
+				Description: "The time window the SLO is computed over.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"duration": {
+							Description: "The duration of the window, for example 7d, 30d or 90d for rolling windows, 1w or 1M for calendar aligned ones.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"type": {
+							Description:  "The window type: rolling or calendarAligned.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"rolling", "calendarAligned"}, false),
+						},
+					},
+				},
+			},
+			"budgeting_method": {
+				Description:  "Counts the good events (occurrences) or the good time slices (timeslices).",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"occurrences", "timeslices"}, false),
+			},
+			"objective": {
+				Description: "The objective of the SLO.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"target": {
+							Description:  "The target ratio of good events or time slices, for example 0.99.",
+							Type:         schema.TypeFloat,
+							Required:     true,
+							ValidateFunc: validation.FloatBetween(0, 1),
+						},
+						"timeslice_target": {
+							Description:  "The target ratio of good events in a time slice, for the timeslices budgeting method.",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ValidateFunc: validation.FloatBetween(0, 1),
+						},
+						"timeslice_window": {
+							Description: "The duration of a time slice, for example 5m, for the timeslices budgeting method.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"settings": {
+				Description: "The settings of the SLO transforms.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sync_delay": {
+							Description: "The delay before the source documents are processed, for example 1m.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"frequency": {
+							Description: "The interval between two transform checkpoints, for example 1m.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"prevent_initial_backfill": {
+							Description: "Only processes the documents received after the SLO creation.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"group_by": {
+				Description: "The fields an SLO instance is created for, for example service.name. Several fields require Kibana 8.14 or later.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags": {
+				Description: "The tags of the SLO.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithSpace,
+		},
+	}
+}
+
+func resourceSloCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	slo, err := deflateSlo(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	slo.Id = d.Get("slo_id").(string)
+	created, err := client.CreateSlo(d.Get("space_id").(string), slo)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(created.Id)
+	return resourceSloRead(ctx, d, meta)
+}
+
+func resourceSloRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	slo, err := client.ReadSlo(d.Get("space_id").(string), d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("slo_id", d.Id())
+	d.Set("name", slo.Name)
+	d.Set("description", slo.Description)
+	for name, indicatorType := range sloIndicatorBlocks {
+		if indicatorType != slo.Indicator.Type {
+			d.Set(name, nil)
+			continue
+		}
+		indicator, err := flattenSloIndicator(slo.Indicator)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set(name, []map[string]interface{}{indicator})
+	}
+	d.Set("time_window", []map[string]interface{}{{
+		"duration": slo.TimeWindow.Duration,
+		"type":     slo.TimeWindow.Type,
+	}})
+	d.Set("budgeting_method", slo.BudgetingMethod)
+	d.Set("objective", []map[string]interface{}{{
+		"target":           slo.Objective.Target,
+		"timeslice_target": slo.Objective.TimesliceTarget,
+		"timeslice_window": slo.Objective.TimesliceWindow,
+	}})
+	if slo.Settings != nil {
+		d.Set("settings", []map[string]interface{}{{
+			"sync_delay":               slo.Settings.SyncDelay,
+			"frequency":                slo.Settings.Frequency,
+			"prevent_initial_backfill": slo.Settings.PreventInitialBackfill,
+		}})
+	}
+	d.Set("group_by", []string(slo.GroupBy))
+	d.Set("tags", slo.Tags)
+
+	return diags
+}
+
+func resourceSloUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	slo, err := deflateSlo(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.UpdateSlo(d.Get("space_id").(string), d.Id(), slo)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceSloRead(ctx, d, meta)
+}
+
+func resourceSloDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteSlo(d.Get("space_id").(string), d.Id())
+	if err != nil && !errors.Is(err, mykibana.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourceSloCustomizeDiff rejects the attributes the detected Kibana doesn't
+// support.
+func resourceSloCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("group_by").([]interface{})) > 1 {
+		return checkKibanaVersion(meta.(mykibana.KibanaAPI), 8, 14, "group_by with several fields")
+	}
+	return nil
+}
+
+func deflateSlo(d *schema.ResourceData) (mykibana.Slo, error) {
+	slo := mykibana.Slo{}
+	slo.Name = d.Get("name").(string)
+	slo.Description = d.Get("description").(string)
+	for name, indicatorType := range sloIndicatorBlocks {
+		indicatorList := d.Get(name).([]interface{})
+		if len(indicatorList) == 0 || indicatorList[0] == nil {
+			continue
+		}
+		indicator, err := deflateSloIndicator(indicatorType, indicatorList[0].(map[string]interface{}))
+		if err != nil {
+			return slo, err
+		}
+		slo.Indicator = indicator
+	}
+	timeWindow := d.Get("time_window").([]interface{})[0].(map[string]interface{})
+	slo.TimeWindow = mykibana.SloTimeWindow{
+		Duration: timeWindow["duration"].(string),
+		Type:     timeWindow["type"].(string),
+	}
+	slo.BudgetingMethod = d.Get("budgeting_method").(string)
+	objective := d.Get("objective").([]interface{})[0].(map[string]interface{})
+	slo.Objective = mykibana.SloObjective{
+		Target:          objective["target"].(float64),
+		TimesliceTarget: objective["timeslice_target"].(float64),
+		TimesliceWindow: objective["timeslice_window"].(string),
+	}
+	if settingsList := d.Get("settings").([]interface{}); len(settingsList) > 0 && settingsList[0] != nil {
+		settings := settingsList[0].(map[string]interface{})
+		slo.Settings = &mykibana.SloSettings{
+			SyncDelay:              settings["sync_delay"].(string),
+			Frequency:              settings["frequency"].(string),
+			PreventInitialBackfill: settings["prevent_initial_backfill"].(bool),
+		}
+	}
+	slo.GroupBy = deflateStringList(d.Get("group_by").([]interface{}))
+	slo.Tags = deflateStringList(d.Get("tags").([]interface{}))
+	return slo, nil
+}
+
+func deflateSloIndicator(indicatorType string, indicator map[string]interface{}) (mykibana.SloIndicator, error) {
+	var params interface{}
+	switch indicatorType {
+	case mykibana.SloIndicatorKqlCustom:
+		params = mykibana.SloKqlCustomParams{
+			Index:          indicator["index"].(string),
+			DataViewId:     indicator["data_view_id"].(string),
+			Filter:         mykibana.SloKqlQuery(indicator["filter"].(string)),
+			Good:           mykibana.SloKqlQuery(indicator["good"].(string)),
+			Total:          mykibana.SloKqlQuery(indicator["total"].(string)),
+			TimestampField: indicator["timestamp_field"].(string),
+		}
+	case mykibana.SloIndicatorMetricCustom:
+		params = mykibana.SloMetricCustomParams{
+			Index:          indicator["index"].(string),
+			DataViewId:     indicator["data_view_id"].(string),
+			Filter:         mykibana.SloKqlQuery(indicator["filter"].(string)),
+			TimestampField: indicator["timestamp_field"].(string),
+			Good:           deflateSloMetricCustomSide(indicator["good"].([]interface{})),
+			Total:          deflateSloMetricCustomSide(indicator["total"].([]interface{})),
+		}
+	case mykibana.SloIndicatorHistogramCustom:
+		params = mykibana.SloHistogramCustomParams{
+			Index:          indicator["index"].(string),
+			DataViewId:     indicator["data_view_id"].(string),
+			Filter:         mykibana.SloKqlQuery(indicator["filter"].(string)),
+			TimestampField: indicator["timestamp_field"].(string),
+			Good:           deflateSloHistogramSide(indicator["good"].([]interface{})),
+			Total:          deflateSloHistogramSide(indicator["total"].([]interface{})),
+		}
+	case mykibana.SloIndicatorApmLatency, mykibana.SloIndicatorApmAvailability:
+		apm := mykibana.SloApmParams{
+			Service:         indicator["service"].(string),
+			Environment:     indicator["environment"].(string),
+			TransactionType: indicator["transaction_type"].(string),
+			TransactionName: indicator["transaction_name"].(string),
+			Index:           indicator["index"].(string),
+			Filter:          mykibana.SloKqlQuery(indicator["filter"].(string)),
+		}
+		if threshold, ok := indicator["threshold"]; ok {
+			apm.Threshold = threshold.(float64)
+		}
+		params = apm
+	case mykibana.SloIndicatorTimesliceMetric:
+		params = mykibana.SloTimesliceMetricParams{
+			Index:          indicator["index"].(string),
+			DataViewId:     indicator["data_view_id"].(string),
+			Filter:         mykibana.SloKqlQuery(indicator["filter"].(string)),
+			TimestampField: indicator["timestamp_field"].(string),
+			Metric: mykibana.SloTimesliceMetricDefinition{
+				Metrics:    deflateSloMetrics(indicator["metric"].([]interface{})),
+				Equation:   indicator["equation"].(string),
+				Comparator: indicator["comparator"].(string),
+				Threshold:  indicator["threshold"].(float64),
+			},
+		}
+	}
+	jsonParams, err := json.Marshal(params)
+	if err != nil {
+		return mykibana.SloIndicator{}, err
+	}
+	return mykibana.SloIndicator{Type: indicatorType, Params: jsonParams}, nil
+}
+
+func deflateSloMetricCustomSide(sideList []interface{}) mykibana.SloMetricCustomSide {
+	side := sideList[0].(map[string]interface{})
+	return mykibana.SloMetricCustomSide{
+		Metrics:  deflateSloMetrics(side["metric"].([]interface{})),
+		Equation: side["equation"].(string),
+	}
+}
+
+func deflateSloMetrics(metricList []interface{}) []mykibana.SloMetric {
+	metrics := make([]mykibana.SloMetric, 0, len(metricList))
+	for _, flatMetric := range metricList {
+		metric := flatMetric.(map[string]interface{})
+		metrics = append(metrics, mykibana.SloMetric{
+			Name:        metric["name"].(string),
+			Aggregation: metric["aggregation"].(string),
+			Field:       metric["field"].(string),
+			Percentile:  metric["percentile"].(float64),
+			Filter:      mykibana.SloKqlQuery(metric["filter"].(string)),
+		})
+	}
+	return metrics
+}
+
+func deflateSloHistogramSide(sideList []interface{}) mykibana.SloHistogramSide {
+	side := sideList[0].(map[string]interface{})
+	histogram := mykibana.SloHistogramSide{
+		Aggregation: side["aggregation"].(string),
+		Field:       side["field"].(string),
+		Filter:      mykibana.SloKqlQuery(side["filter"].(string)),
+	}
+	// from and to are only used by range aggregations, where 0 is a valid
+	// bound.
+	if histogram.Aggregation == "range" {
+		from := side["from"].(float64)
+		to := side["to"].(float64)
+		histogram.From = &from
+		histogram.To = &to
+	}
+	return histogram
+}
+
+func flattenSloIndicator(indicator mykibana.SloIndicator) (map[string]interface{}, error) {
+	switch indicator.Type {
+	case mykibana.SloIndicatorKqlCustom:
+		var params mykibana.SloKqlCustomParams
+		if err := json.Unmarshal(indicator.Params, &params); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"index":           params.Index,
+			"data_view_id":    params.DataViewId,
+			"filter":          string(params.Filter),
+			"good":            string(params.Good),
+			"total":           string(params.Total),
+			"timestamp_field": params.TimestampField,
+		}, nil
+	case mykibana.SloIndicatorMetricCustom:
+		var params mykibana.SloMetricCustomParams
+		if err := json.Unmarshal(indicator.Params, &params); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"index":           params.Index,
+			"data_view_id":    params.DataViewId,
+			"filter":          string(params.Filter),
+			"timestamp_field": params.TimestampField,
+			"good":            flattenSloMetricCustomSide(params.Good),
+			"total":           flattenSloMetricCustomSide(params.Total),
+		}, nil
+	case mykibana.SloIndicatorHistogramCustom:
+		var params mykibana.SloHistogramCustomParams
+		if err := json.Unmarshal(indicator.Params, &params); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"index":           params.Index,
+			"data_view_id":    params.DataViewId,
+			"filter":          string(params.Filter),
+			"timestamp_field": params.TimestampField,
+			"good":            flattenSloHistogramSide(params.Good),
+			"total":           flattenSloHistogramSide(params.Total),
+		}, nil
+	case mykibana.SloIndicatorApmLatency, mykibana.SloIndicatorApmAvailability:
+		var params mykibana.SloApmParams
+		if err := json.Unmarshal(indicator.Params, &params); err != nil {
+			return nil, err
+		}
+		apm := map[string]interface{}{
+			"service":          params.Service,
+			"environment":      params.Environment,
+			"transaction_type": params.TransactionType,
+			"transaction_name": params.TransactionName,
+			"index":            params.Index,
+			"filter":           string(params.Filter),
+		}
+		if indicator.Type == mykibana.SloIndicatorApmLatency {
+			apm["threshold"] = params.Threshold
+		}
+		return apm, nil
+	case mykibana.SloIndicatorTimesliceMetric:
+		var params mykibana.SloTimesliceMetricParams
+		if err := json.Unmarshal(indicator.Params, &params); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"index":           params.Index,
+			"data_view_id":    params.DataViewId,
+			"filter":          string(params.Filter),
+			"timestamp_field": params.TimestampField,
+			"metric":          flattenSloMetrics(params.Metric.Metrics),
+			"equation":        params.Metric.Equation,
+			"comparator":      params.Metric.Comparator,
+			"threshold":       params.Metric.Threshold,
+		}, nil
+	}
+	return nil, errors.Errorf("Unsupported SLO indicator type %s", indicator.Type)
+}
+
+func flattenSloMetricCustomSide(side mykibana.SloMetricCustomSide) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"metric":   flattenSloMetrics(side.Metrics),
+		"equation": side.Equation,
+	}}
+}
+
+func flattenSloMetrics(metrics []mykibana.SloMetric) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(metrics))
+	for _, m := range metrics {
+		res = append(res, map[string]interface{}{
+			"name":        m.Name,
+			"aggregation": m.Aggregation,
+			"field":       m.Field,
+			"percentile":  m.Percentile,
+			"filter":      string(m.Filter),
+		})
+	}
+	return res
+}
+
+func flattenSloHistogramSide(side mykibana.SloHistogramSide) []map[string]interface{} {
+	histogram := map[string]interface{}{
+		"aggregation": side.Aggregation,
+		"field":       side.Field,
+		"filter":      string(side.Filter),
+	}
+	if side.From != nil {
+		histogram["from"] = *side.From
+	}
+	if side.To != nil {
+		histogram["to"] = *side.To
+	}
+	return []map[string]interface{}{histogram}
+}
@@ -0,0 +1,95 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	myprovider "github.com/qonto/terraform-provider-kibana/internal/provider"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func TestKibanaSlo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getSloConfig("Checkout availability", 0.99),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSloExists("kibana_slo.test"),
+					resource.TestCheckResourceAttr("kibana_slo.test", "objective.0.target", "0.99"),
+				),
+			},
+			{
+				Config: getSloConfig("Checkout requests availability", 0.995),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_slo.test", "name", "Checkout requests availability"),
+					resource.TestCheckResourceAttr("kibana_slo.test", "objective.0.target", "0.995"),
+				),
+			},
+		},
+	})
+}
+
+func getSloConfig(name string, target float64) string {
+	return getSloConfigWithGroupBy(name, target, `["host.name"]`)
+}
+
+func getSloConfigWithGroupBy(name string, target float64, groupBy string) string {
+	return fmt.Sprintf(`
+	resource "kibana_slo" "test" {
+    name = "%s"
+    kql_custom_indicator {
+      index = "logs-*"
+      good  = "http.response.status_code < 500"
+      total = "http.response.status_code : *"
+    }
+    time_window {
+      duration = "30d"
+      type     = "rolling"
+    }
+    budgeting_method = "occurrences"
+    objective {
+      target = %g
+    }
+    group_by = %s
+    }
+	`, name, target, groupBy)
+}
+
+func TestKibanaSloGroupByFieldsRequiresKibana814(t *testing.T) {
+	k := mykibana.KibanaMockClient{VersionNumber: "8.13.4"}
+	resource.Test(t, resource.TestCase{
+		Providers: map[string]*schema.Provider{
+			"kibana": myprovider.New(&k)(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: getSloConfig("Checkout availability", 0.99),
+			},
+			{
+				Config:      getSloConfigWithGroupBy("Checkout availability", 0.99, `["host.name", "service.name"]`),
+				ExpectError: regexp.MustCompile("group_by with several fields requires Kibana 8.14 or later"),
+			},
+		},
+	})
+}
+
+func testCheckSloExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SLO ID set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,405 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+var syntheticsMonitorTypes = []string{"http", "tcp", "icmp", "browser"}
+
+func resourceSyntheticsMonitor() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a monitor of the Synthetics app. Exactly one of the http, tcp, icmp and browser blocks sets the monitor type.",
+
+		CreateContext: resourceSyntheticsMonitorCreate,
+		ReadContext:   resourceSyntheticsMonitorRead,
+		UpdateContext: resourceSyntheticsMonitorUpdate,
+		DeleteContext: resourceSyntheticsMonitorDelete,
+		CustomizeDiff: resourceSyntheticsMonitorCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Description: "An identifier for the space. If space_id is not provided, the default space is used.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "The name of the monitor.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"type": {
+				Description: "The monitor type, set by the http, tcp, icmp or browser block.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"enabled": {
+				Description: "Enables the monitor.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"schedule": {
+				Description:  "The interval between two checks, in the schedule_unit: 1, 3, 5, 10, 15, 30, 60, 120 or 240 minutes, or 10 or 30 seconds.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntInSlice([]int{1, 3, 5, 10, 15, 30, 60, 120, 240}),
+			},
+			"schedule_unit": {
+				Description:  "The unit of the schedule: m for minutes or s for seconds. Browser monitors don't support schedules in seconds.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "m",
+				ValidateFunc: validation.StringInSlice([]string{"m", "s"}, false),
+			},
+			"locations": {
+				Description:  "The Elastic managed locations running the monitor, for example us_central.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"locations", "private_locations"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"private_locations": {
+				Description:  "The IDs of the private locations running the monitor.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"locations", "private_locations"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"timeout": {
+				Description: "The check timeout in seconds. Kibana uses a default depending on the monitor type when not provided.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"alert_status_enabled": {
+				Description: "Enables the monitor status alerts.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"alert_tls_enabled": {
+				Description: "Enables the TLS certificate alerts.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"tags": {
+				Description: "The tags of the monitor.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"params": {
+				Description:      "The monitor parameters as JSON, usable as ${name} in the monitor settings and scripts.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: rawJsonEqual,
+				ValidateFunc:     validation.StringIsJSON,
+			},
+			"namespace": {
+				Description: "The namespace of the monitor data streams. The space namespace is used when not provided.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"http": {
+				Description:  "Checks an HTTP endpoint.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: syntheticsMonitorTypes,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Description: "The URL to check.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"max_redirects": {
+							Description: "The number of redirects to follow.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+						},
+						"mode": {
+							Description:  "Checks any or all of the IPs the host resolves to: any or all.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "any",
+							ValidateFunc: validation.StringInSlice([]string{"any", "all"}, false),
+						},
+						"ipv4": {
+							Description: "Checks the IPv4 addresses of the host.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"ipv6": {
+							Description: "Checks the IPv6 addresses of the host.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"proxy_url": {
+							Description: "The URL of the proxy used to reach the endpoint.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"tcp": {
+				Description:  "Checks a TCP endpoint.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: syntheticsMonitorTypes,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Description: "The host:port to check.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"icmp": {
+				Description:  "Pings a host.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: syntheticsMonitorTypes,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Description: "The host to ping.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"wait": {
+							Description: "The time to wait between two pings, in seconds.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+						},
+					},
+				},
+			},
+			"browser": {
+				Description:  "Runs a browser journey.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: syntheticsMonitorTypes,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"inline_script": {
+							Description: "The journey steps, written with the Synthetics agent API.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"screenshots": {
+							Description:  "Captures screenshots: on, off or only-on-failure.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "on",
+							ValidateFunc: validation.StringInSlice([]string{"on", "off", "only-on-failure"}, false),
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithSpace,
+		},
+	}
+}
+
+func resourceSyntheticsMonitorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	monitor, err := client.CreateSyntheticsMonitor(d.Get("space_id").(string), deflateSyntheticsMonitor(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(monitor.Id)
+	return resourceSyntheticsMonitorRead(ctx, d, meta)
+}
+
+func resourceSyntheticsMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	monitor, err := client.ReadSyntheticsMonitor(d.Get("space_id").(string), d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("name", monitor.Name)
+	d.Set("type", monitor.Type)
+	d.Set("enabled", monitor.Enabled)
+	if monitor.Schedule != nil {
+		d.Set("schedule", monitor.Schedule.Number)
+		d.Set("schedule_unit", monitor.Schedule.Unit)
+	}
+	d.Set("locations", monitor.Locations)
+	d.Set("private_locations", monitor.PrivateLocations)
+	d.Set("timeout", monitor.Timeout)
+	if monitor.Alert != nil {
+		d.Set("alert_status_enabled", monitor.Alert.Status.Enabled)
+		d.Set("alert_tls_enabled", monitor.Alert.Tls.Enabled)
+	}
+	d.Set("tags", monitor.Tags)
+	d.Set("params", flattenRawJson(monitor.Params))
+	d.Set("namespace", monitor.Namespace)
+	for _, monitorType := range syntheticsMonitorTypes {
+		d.Set(monitorType, nil)
+	}
+	switch monitor.Type {
+	case "http":
+		d.Set("http", []map[string]interface{}{{
+			"url":           monitor.Url,
+			"max_redirects": monitor.MaxRedirects,
+			"mode":          monitor.Mode,
+			"ipv4":          monitor.Ipv4 == nil || *monitor.Ipv4,
+			"ipv6":          monitor.Ipv6 == nil || *monitor.Ipv6,
+			"proxy_url":     monitor.ProxyUrl,
+		}})
+	case "tcp":
+		d.Set("tcp", []map[string]interface{}{{
+			"host": monitor.Host,
+		}})
+	case "icmp":
+		d.Set("icmp", []map[string]interface{}{{
+			"host": monitor.Host,
+			"wait": monitor.Wait,
+		}})
+	case "browser":
+		d.Set("browser", []map[string]interface{}{{
+			"inline_script": monitor.InlineScript,
+			"screenshots":   monitor.Screenshots,
+		}})
+	}
+
+	return diags
+}
+
+func resourceSyntheticsMonitorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	_, err := client.UpdateSyntheticsMonitor(d.Get("space_id").(string), d.Id(), deflateSyntheticsMonitor(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceSyntheticsMonitorRead(ctx, d, meta)
+}
+
+func resourceSyntheticsMonitorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteSyntheticsMonitor(d.Get("space_id").(string), d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// resourceSyntheticsMonitorCustomizeDiff checks the schedules in seconds and
+// replaces the monitor when its type changes, Kibana doesn't allow updating
+// it.
+func resourceSyntheticsMonitorCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	monitorType := ""
+	for _, t := range syntheticsMonitorTypes {
+		if len(d.Get(t).([]interface{})) > 0 {
+			monitorType = t
+		}
+	}
+	if d.Get("schedule_unit").(string) == "s" {
+		if schedule := d.Get("schedule").(int); schedule != 10 && schedule != 30 {
+			return fmt.Errorf("A schedule in seconds must be 10 or 30, got %d", schedule)
+		}
+		if monitorType == "browser" {
+			return fmt.Errorf("Browser monitors don't support schedules in seconds")
+		}
+	}
+	if monitorType == "" || monitorType == d.Get("type").(string) {
+		return nil
+	}
+	if err := d.SetNew("type", monitorType); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return d.ForceNew("type")
+}
+
+func deflateSyntheticsMonitor(d *schema.ResourceData) mykibana.SyntheticsMonitor {
+	monitor := mykibana.SyntheticsMonitor{}
+	monitor.Name = d.Get("name").(string)
+	monitor.Enabled = d.Get("enabled").(bool)
+	monitor.Schedule = &mykibana.SyntheticsSchedule{
+		Number: d.Get("schedule").(int),
+		Unit:   d.Get("schedule_unit").(string),
+	}
+	monitor.Locations = deflateStringSet(d.Get("locations").(*schema.Set))
+	monitor.PrivateLocations = deflateStringSet(d.Get("private_locations").(*schema.Set))
+	monitor.Timeout = d.Get("timeout").(int)
+	monitor.Alert = &mykibana.SyntheticsMonitorAlert{
+		Status: mykibana.SyntheticsMonitorAlertToggle{Enabled: d.Get("alert_status_enabled").(bool)},
+		Tls:    mykibana.SyntheticsMonitorAlertToggle{Enabled: d.Get("alert_tls_enabled").(bool)},
+	}
+	monitor.Tags = deflateStringList(d.Get("tags").([]interface{}))
+	if params := d.Get("params").(string); params != "" {
+		monitor.Params = json.RawMessage(params)
+	}
+	monitor.Namespace = d.Get("namespace").(string)
+	if httpList := d.Get("http").([]interface{}); len(httpList) > 0 && httpList[0] != nil {
+		http := httpList[0].(map[string]interface{})
+		ipv4 := http["ipv4"].(bool)
+		ipv6 := http["ipv6"].(bool)
+		monitor.Type = "http"
+		monitor.Url = http["url"].(string)
+		monitor.MaxRedirects = http["max_redirects"].(int)
+		monitor.Mode = http["mode"].(string)
+		monitor.Ipv4 = &ipv4
+		monitor.Ipv6 = &ipv6
+		monitor.ProxyUrl = http["proxy_url"].(string)
+	}
+	if tcpList := d.Get("tcp").([]interface{}); len(tcpList) > 0 && tcpList[0] != nil {
+		tcp := tcpList[0].(map[string]interface{})
+		monitor.Type = "tcp"
+		monitor.Host = tcp["host"].(string)
+	}
+	if icmpList := d.Get("icmp").([]interface{}); len(icmpList) > 0 && icmpList[0] != nil {
+		icmp := icmpList[0].(map[string]interface{})
+		monitor.Type = "icmp"
+		monitor.Host = icmp["host"].(string)
+		monitor.Wait = icmp["wait"].(int)
+	}
+	if browserList := d.Get("browser").([]interface{}); len(browserList) > 0 && browserList[0] != nil {
+		browser := browserList[0].(map[string]interface{})
+		monitor.Type = "browser"
+		monitor.InlineScript = browser["inline_script"].(string)
+		monitor.Screenshots = browser["screenshots"].(string)
+	}
+	return monitor
+}
@@ -0,0 +1,81 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaSyntheticsMonitor(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getSyntheticsMonitorConfig("Website", 3),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSyntheticsMonitorExists("kibana_synthetics_monitor.test"),
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor.test", "type", "http"),
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor.test", "http.0.url", "https://www.elastic.co"),
+				),
+			},
+			{
+				Config: getSyntheticsMonitorConfig("Elastic website", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor.test", "name", "Elastic website"),
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor.test", "schedule", "10"),
+				),
+			},
+			{
+				Config: getSyntheticsMonitorConfigWithUnit("Elastic website", 30, "s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor.test", "schedule", "30"),
+					resource.TestCheckResourceAttr("kibana_synthetics_monitor.test", "schedule_unit", "s"),
+				),
+			},
+			{
+				Config:      getSyntheticsMonitorConfigWithUnit("Elastic website", 5, "s"),
+				ExpectError: regexp.MustCompile("A schedule in seconds must be 10 or 30"),
+			},
+		},
+	})
+}
+
+func getSyntheticsMonitorConfig(name string, schedule int) string {
+	return getSyntheticsMonitorConfigWithUnit(name, schedule, "m")
+}
+
+func getSyntheticsMonitorConfigWithUnit(name string, schedule int, unit string) string {
+	return fmt.Sprintf(`
+	resource "kibana_synthetics_monitor" "test" {
+    name          = "%s"
+    schedule      = %d
+    schedule_unit = "%s"
+    locations     = ["us_central"]
+    tags          = ["website"]
+    params        = jsonencode({ path = "/" })
+    http {
+      url           = "https://www.elastic.co"
+      max_redirects = 2
+    }
+    }
+	`, name, schedule, unit)
+}
+
+func testCheckSyntheticsMonitorExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No monitor ID set")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+)
+
+func resourceSyntheticsPrivateLocation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Synthetics private location, running monitors on the agents of a Fleet agent policy. Private locations can't be updated, any change replaces them.",
+
+		CreateContext: resourceSyntheticsPrivateLocationCreate,
+		ReadContext:   resourceSyntheticsPrivateLocationRead,
+		DeleteContext: resourceSyntheticsPrivateLocationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"label": {
+				Description: "The name of the private location.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"agent_policy_id": {
+				Description: "The ID of the Fleet agent policy whose agents run the monitors.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"tags": {
+				Description: "The tags of the private location.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"geo": {
+				Description: "The coordinates of the private location.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"lat": {
+							Description: "The latitude.",
+							Type:        schema.TypeFloat,
+							Required:    true,
+							ForceNew:    true,
+						},
+						"lon": {
+							Description: "The longitude.",
+							Type:        schema.TypeFloat,
+							Required:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceSyntheticsPrivateLocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(mykibana.KibanaAPI)
+	location := mykibana.SyntheticsPrivateLocation{
+		Label:         d.Get("label").(string),
+		AgentPolicyId: d.Get("agent_policy_id").(string),
+		Tags:          deflateStringList(d.Get("tags").([]interface{})),
+	}
+	if geoList := d.Get("geo").([]interface{}); len(geoList) > 0 && geoList[0] != nil {
+		geo := geoList[0].(map[string]interface{})
+		location.Geo = &mykibana.SyntheticsPrivateLocationGeo{
+			Lat: geo["lat"].(float64),
+			Lon: geo["lon"].(float64),
+		}
+	}
+	created, err := client.CreateSyntheticsPrivateLocation(location)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(created.Id)
+	return resourceSyntheticsPrivateLocationRead(ctx, d, meta)
+}
+
+func resourceSyntheticsPrivateLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	location, err := client.ReadSyntheticsPrivateLocation(d.Id())
+	if errors.Is(err, mykibana.ErrNotFound) {
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("label", location.Label)
+	d.Set("agent_policy_id", location.AgentPolicyId)
+	d.Set("tags", location.Tags)
+	if location.Geo != nil {
+		d.Set("geo", []map[string]interface{}{{
+			"lat": location.Geo.Lat,
+			"lon": location.Geo.Lon,
+		}})
+	} else {
+		d.Set("geo", nil)
+	}
+
+	return diags
+}
+
+func resourceSyntheticsPrivateLocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(mykibana.KibanaAPI)
+	err := client.DeleteSyntheticsPrivateLocation(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,64 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKibanaSyntheticsPrivateLocation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: getSyntheticsPrivateLocationConfig("Paris"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSyntheticsPrivateLocationExists("kibana_synthetics_private_location.test"),
+					resource.TestCheckResourceAttrPair("kibana_synthetics_private_location.test", "agent_policy_id", "kibana_fleet_agent_policy.test", "id"),
+				),
+			},
+			{
+				Config: getSyntheticsPrivateLocationConfig("Paris datacenter"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("kibana_synthetics_private_location.test", "label", "Paris datacenter"),
+				),
+			},
+		},
+	})
+}
+
+func getSyntheticsPrivateLocationConfig(label string) string {
+	return fmt.Sprintf(`
+	resource "kibana_fleet_agent_policy" "test" {
+    name      = "Synthetics"
+    namespace = "default"
+    }
+
+	resource "kibana_synthetics_private_location" "test" {
+    label           = "%s"
+    agent_policy_id = kibana_fleet_agent_policy.test.id
+    geo {
+      lat = 48.86
+      lon = 2.35
+    }
+    }
+	`, label)
+}
+
+func testCheckSyntheticsPrivateLocationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No private location ID set")
+		}
+
+		return nil
+	}
+}
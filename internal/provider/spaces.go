@@ -0,0 +1,19 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importStateWithSpace accepts import IDs in the form <space_id>/<object_id>
+// as well as a bare <object_id> for objects living in the default space.
+func importStateWithSpace(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) == 2 {
+		d.Set("space_id", parts[0])
+		d.SetId(parts[1])
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,283 @@
+package httpClient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Cassette records the HTTP interactions of a client into a file, or replays
+// them without any network, for tests. It is plugged into the client with
+// SetRoundTripper(cassette.RoundTripper). Requests are matched on their
+// method, their path and their JSON body, normalized, and replayed in the
+// recorded order. The credentials are scrubbed from the recorded headers and
+// the sensitive fields of the JSON bodies are redacted like in the logs, on
+// both sides of the match.
+type Cassette struct {
+	path      string
+	recording bool
+	mutex     sync.Mutex
+	cassette  cassetteFile
+	replayed  []bool
+}
+
+type cassetteFile struct {
+	Interactions []cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method  string       `json:"method"`
+	Path    string       `json:"path"`
+	Headers http.Header  `json:"headers,omitempty"`
+	Body    cassetteBody `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status  int          `json:"status"`
+	Headers http.Header  `json:"headers,omitempty"`
+	Body    cassetteBody `json:"body,omitempty"`
+}
+
+// cassetteBody is stored as JSON when it is a JSON object or array, to keep
+// the cassettes readable, and as a string otherwise. Bodies which aren't
+// UTF-8, such as package archives, are stored base64 encoded with the
+// cassetteBase64Prefix, like the text bodies starting with it.
+type cassetteBody []byte
+
+const cassetteBase64Prefix = "base64:"
+
+func (b cassetteBody) MarshalJSON() ([]byte, error) {
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return trimmed, nil
+	}
+	if utf8.Valid(b) && !bytes.HasPrefix(b, []byte(cassetteBase64Prefix)) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(cassetteBase64Prefix + base64.StdEncoding.EncodeToString(b))
+}
+
+func (b *cassetteBody) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if strings.HasPrefix(text, cassetteBase64Prefix) {
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(text, cassetteBase64Prefix))
+			if err != nil {
+				return err
+			}
+			*b = decoded
+			return nil
+		}
+		*b = cassetteBody(text)
+		return nil
+	}
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, data); err != nil {
+		return err
+	}
+	*b = compacted.Bytes()
+	return nil
+}
+
+// NewCassetteRecorder returns a cassette recording the interactions, which
+// are written to a file by Save.
+func NewCassetteRecorder() *Cassette {
+	return &Cassette{recording: true}
+}
+
+// LoadCassette returns a cassette replaying the interactions recorded in
+// path.
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{path: path}
+	if err := json.Unmarshal(content, &c.cassette); err != nil {
+		return nil, fmt.Errorf("Invalid cassette %s: %s", path, err)
+	}
+	c.replayed = make([]bool, len(c.cassette.Interactions))
+	return c, nil
+}
+
+// RoundTripper records the requests sent through next, or replays them
+// without calling next.
+func (c *Cassette) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	return &cassetteTransport{next: next, cassette: c}
+}
+
+// Save writes the recorded interactions to path.
+func (c *Cassette) Save(path string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.recording {
+		return fmt.Errorf("Cassette %s is not recording", c.path)
+	}
+	content, err := json.MarshalIndent(c.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// Unplayed returns the number of recorded interactions which haven't been
+// replayed yet.
+func (c *Cassette) Unplayed() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	unplayed := 0
+	for _, replayed := range c.replayed {
+		if !replayed {
+			unplayed++
+		}
+	}
+	return unplayed
+}
+
+type cassetteTransport struct {
+	next     http.RoundTripper
+	cassette *Cassette
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	if t.cassette.recording {
+		return t.record(req, body)
+	}
+	return t.cassette.replay(req, body)
+}
+
+func (t *cassetteTransport) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	t.cassette.mutex.Lock()
+	defer t.cassette.mutex.Unlock()
+	t.cassette.cassette.Interactions = append(t.cassette.cassette.Interactions, cassetteInteraction{
+		Request: cassetteRequest{
+			Method:  req.Method,
+			Path:    cassettePath(req),
+			Headers: scrubHeaders(req.Header),
+			Body:    scrubBody(req.URL.Path, body),
+		},
+		Response: cassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: scrubHeaders(resp.Header),
+			Body:    scrubBody(req.URL.Path, respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (c *Cassette) replay(req *http.Request, body []byte) (*http.Response, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	path := cassettePath(req)
+	normalized := normalizeBody(req.URL.Path, body)
+	for i, interaction := range c.cassette.Interactions {
+		if c.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.Path != path ||
+			normalizeBody(req.URL.Path, interaction.Request.Body) != normalized {
+			continue
+		}
+		c.replayed[i] = true
+		// The recorded body is compacted, its original length doesn't apply.
+		header := interaction.Response.Headers.Clone()
+		header.Del("Content-Length")
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("No interaction recorded in %s for %s %s with body %s", c.path, req.Method, path, normalized)
+}
+
+// cassettePath returns the path of the request with its query parameters
+// sorted. The host isn't recorded, so that cassettes can be replayed against
+// any address.
+func cassettePath(req *http.Request) string {
+	if req.URL.RawQuery == "" {
+		return req.URL.Path
+	}
+	return fmt.Sprintf("%s?%s", req.URL.Path, req.URL.Query().Encode())
+}
+
+// normalizeBody returns JSON bodies sent to path redacted and compacted with
+// sorted keys, so that a request matches its recording regardless of its
+// formatting and of its secrets. Other bodies are returned unchanged.
+func normalizeBody(path string, body []byte) string {
+	return string(scrubBody(path, body))
+}
+
+// scrubBody returns JSON bodies sent to or received from path with the value
+// of their sensitive fields redacted, compacted with sorted keys. Other bodies
+// are returned unchanged.
+func scrubBody(path string, body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		return body
+	}
+	scrubbed, err := json.Marshal(redactValue(path, decoded))
+	if err != nil {
+		return body
+	}
+	return scrubbed
+}
+
+// scrubHeaders returns the headers without the credentials.
+func scrubHeaders(headers http.Header) http.Header {
+	scrubbed := make(http.Header, len(headers))
+	for name, values := range headers {
+		if isSensitiveHeader(name) {
+			continue
+		}
+		scrubbed[name] = values
+	}
+	return scrubbed
+}
@@ -0,0 +1,98 @@
+package httpClient
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "sid=cookie-value")
+		w.Write([]byte(`{"id":"1","name":"a","secrets":{"token":"response-secret"}}`))
+	}))
+	defer server.Close()
+	headers := map[string]string{"Authorization": "Basic credentials", "kbn-xsrf": "terraform"}
+
+	recorder := NewCassetteRecorder()
+	client := CreateHTTPClient()
+	client.SetRoundTripper(recorder.RoundTripper)
+	if _, status, err := client.Post(server.URL+"/api/actions/connector", headers, []byte(`{"name":"a","secrets":{"password":"request-secret"}}`)); err != nil || status != 200 {
+		t.Fatalf("Recording: %d %v", status, err)
+	}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"request-secret", "response-secret", "credentials", "cookie-value"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("The cassette contains %s:\n%s", secret, content)
+		}
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = CreateHTTPClient()
+	client.SetRoundTripper(cassette.RoundTripper)
+	if _, _, err := client.Post("http://kibana.test/api/actions/connector", headers, []byte(`{"name":"b"}`)); err == nil || !strings.Contains(err.Error(), "No interaction recorded") {
+		t.Errorf("A request with another body should not be replayed, got %v", err)
+	}
+	// The keys are in another order and the secret differs, which is redacted
+	// on both sides.
+	body, status, err := client.Post("http://kibana.test/api/actions/connector", headers, []byte(`{
+		"secrets": {"password": "another-secret"},
+		"name": "a"
+	}`))
+	if err != nil || status != 200 || string(body) != `{"id":"1","name":"a","secrets":"REDACTED"}` {
+		t.Errorf("Replaying: %d %s %v", status, body, err)
+	}
+	if unplayed := cassette.Unplayed(); unplayed != 0 {
+		t.Errorf("%d interactions were not replayed", unplayed)
+	}
+}
+
+func TestCassetteBinaryBodies(t *testing.T) {
+	archive := []byte{'P', 'K', 0x03, 0x04, 0xff, 0xfe, 0x00, 0x80}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	recorder := NewCassetteRecorder()
+	client := CreateHTTPClient()
+	client.SetRoundTripper(recorder.RoundTripper)
+	for _, body := range [][]byte{archive, []byte("base64:not encoded")} {
+		if _, status, err := client.PostBinary(server.URL+"/api/fleet/epm/packages", nil, "application/zip", bytes.NewReader(body)); err != nil || status != 200 {
+			t.Fatalf("Recording: %d %v", status, err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = CreateHTTPClient()
+	client.SetRoundTripper(cassette.RoundTripper)
+	for _, body := range [][]byte{archive, []byte("base64:not encoded")} {
+		replayed, status, err := client.PostBinary("http://kibana.test/api/fleet/epm/packages", nil, "application/zip", bytes.NewReader(body))
+		if err != nil || status != 200 || string(replayed) != string(body) {
+			t.Errorf("Replaying %q: %d %q %v", body, status, replayed, err)
+		}
+	}
+}
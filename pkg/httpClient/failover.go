@@ -0,0 +1,119 @@
+package httpClient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// failoverTransport sends the requests addressed to the first endpoint to the
+// current endpoint, moving to the next one when it is down. Requests whose
+// body can't be sent again are not retried. Non-idempotent requests, such as
+// creations, only fail over when the connection couldn't be established:
+// a 502, 503 or 504 from a proxy may come after Kibana applied them.
+type failoverTransport struct {
+	next      http.RoundTripper
+	endpoints []string
+	mutex     sync.Mutex
+	current   int
+}
+
+// SetEndpoints fails over the requests sent to the first endpoint to the next
+// ones when an endpoint can't be reached, or answers 502, 503 or 504 to an
+// idempotent request.
+func (c *HttpClient) SetEndpoints(endpoints []string) error {
+	trimmed := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if !isValidUrl(endpoint) {
+			return fmt.Errorf("Invalid endpoint %s", endpoint)
+		}
+		trimmed = append(trimmed, strings.TrimSuffix(endpoint, "/"))
+	}
+	c.endpoints = trimmed
+	c.updateTransport()
+	return nil
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(req.URL.String(), t.endpoints[0])
+	if path == req.URL.String() {
+		return t.next.RoundTrip(req)
+	}
+	t.mutex.Lock()
+	start := t.current
+	t.mutex.Unlock()
+
+	canRetry := req.Body == nil || req.GetBody != nil
+	var resp *http.Response
+	var err error
+	for i := 0; i < len(t.endpoints); i++ {
+		current := (start + i) % len(t.endpoints)
+		attempt, reqErr := t.endpointRequest(req, current, path, i > 0)
+		if reqErr != nil {
+			return nil, reqErr
+		}
+		resp, err = t.next.RoundTrip(attempt)
+		if err == nil && !isUnavailableStatus(resp.StatusCode) {
+			t.mutex.Lock()
+			t.current = current
+			t.mutex.Unlock()
+			return resp, nil
+		}
+		if i == len(t.endpoints)-1 || !canRetry {
+			return resp, err
+		}
+		if !isIdempotent(req.Method) && (err == nil || !isDialError(err)) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+	}
+	return resp, err
+}
+
+func isUnavailableStatus(statusCode int) bool {
+	return statusCode == 502 || statusCode == 503 || statusCode == 504
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isDialError reports whether the request failed while connecting, before
+// any of it was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// endpointRequest copies req to the endpoint of index i, reading the body
+// again for the retries.
+func (t *failoverTransport) endpointRequest(req *http.Request, i int, path string, retry bool) (*http.Request, error) {
+	endpointUrl, err := url.Parse(t.endpoints[i] + path)
+	if err != nil {
+		return nil, err
+	}
+	attempt := req.Clone(req.Context())
+	attempt.URL = endpointUrl
+	attempt.Host = ""
+	if retry && req.Body != nil {
+		if req.GetBody == nil {
+			return nil, fmt.Errorf("Request body can't be sent again")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attempt.Body = body
+	}
+	return attempt, nil
+}
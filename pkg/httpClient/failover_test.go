@@ -0,0 +1,68 @@
+package httpClient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// countingServer answers status to every request and counts them.
+func countingServer(status int) (*httptest.Server, *int32) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.WriteHeader(status)
+	}))
+	return server, &count
+}
+
+func TestFailoverWhenEndpointIsDown(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	up, count := countingServer(200)
+	defer up.Close()
+	client := CreateHTTPClient()
+	if err := client.SetEndpoints([]string{down.URL, up.URL + "/"}); err != nil {
+		t.Fatal(err)
+	}
+	// Creations fail over too since nothing was sent to the endpoint which
+	// is down.
+	if _, status, err := client.Post(down.URL+"/api/alerting/rule", nil, []byte(`{"name":"rule"}`)); err != nil || status != 200 {
+		t.Fatalf("POST: %d %v", status, err)
+	}
+	if _, status, err := client.Get(down.URL+"/api/status", nil); err != nil || status != 200 {
+		t.Fatalf("GET: %d %v", status, err)
+	}
+	if *count != 2 {
+		t.Errorf("Expected 2 requests on the endpoint which is up, got %d", *count)
+	}
+}
+
+func TestFailoverOnUnavailableStatus(t *testing.T) {
+	unavailable, unavailableCount := countingServer(503)
+	defer unavailable.Close()
+	up, upCount := countingServer(200)
+	defer up.Close()
+	client := CreateHTTPClient()
+	if err := client.SetEndpoints([]string{unavailable.URL, up.URL}); err != nil {
+		t.Fatal(err)
+	}
+	// The creation may have been applied behind the 503, it isn't resent.
+	if _, status, err := client.Post(unavailable.URL+"/api/alerting/rule", nil, []byte(`{"name":"rule"}`)); err != nil || status != 503 {
+		t.Fatalf("POST: %d %v", status, err)
+	}
+	if *upCount != 0 {
+		t.Errorf("A POST answered with 503 should not fail over, got %d requests", *upCount)
+	}
+	if _, status, err := client.Put(unavailable.URL+"/api/alerting/rule/1", nil, []byte(`{"name":"rule"}`)); err != nil || status != 200 {
+		t.Fatalf("PUT: %d %v", status, err)
+	}
+	// The client sticks to the endpoint which answered.
+	if _, status, err := client.Get(unavailable.URL+"/api/status", nil); err != nil || status != 200 {
+		t.Fatalf("GET: %d %v", status, err)
+	}
+	if *unavailableCount != 2 || *upCount != 2 {
+		t.Errorf("Expected 2 requests on each endpoint, got %d and %d", *unavailableCount, *upCount)
+	}
+}
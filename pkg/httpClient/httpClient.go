@@ -2,9 +2,11 @@ package httpClient
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 )
@@ -16,15 +18,81 @@ type HttpClientAPI interface {
 	Post(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error)
 	Patch(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error)
 	Delete(url string, headers map[string]string) ([]byte, int, error)
+	DeleteJson(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error)
 	Put(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error)
+	PostReturnReader(url string, headers map[string]string, jsonBody []byte) (io.ReadCloser, int, error)
+	PostMultipart(url string, headers map[string]string, fieldName, fileName string, content io.Reader) ([]byte, int, error)
+	PostBinary(url string, headers map[string]string, contentType string, content io.Reader) ([]byte, int, error)
+	SetEndpoints(endpoints []string) error
+	SetProxy(proxyUrl string, fromEnvironment bool) error
+	EnableLogging(ctx context.Context)
+	SetRateLimit(requestsPerSecond float64, maxConcurrent, maxRetries int) error
+	SetRoundTripper(wrap func(next http.RoundTripper) http.RoundTripper)
 }
 
 type HttpClient struct {
-	api *http.Client
+	api        *http.Client
+	transport  *http.Transport
+	endpoints  []string
+	logContext context.Context
+	limiter    *rateLimiter
+	wrap       func(next http.RoundTripper) http.RoundTripper
 }
 
 func CreateHTTPClient() HttpClientAPI {
-	return &HttpClient{api: &http.Client{}}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	return &HttpClient{api: &http.Client{Transport: transport}, transport: transport}
+}
+
+// updateTransport stacks the round trippers enabled on the client over the
+// HTTP transport.
+func (c *HttpClient) updateTransport() {
+	var transport http.RoundTripper = c.transport
+	if c.wrap != nil {
+		transport = c.wrap(transport)
+	}
+	if c.logContext != nil {
+		transport = &loggingTransport{next: transport, ctx: c.logContext}
+	}
+	if len(c.endpoints) > 1 {
+		transport = &failoverTransport{next: transport, endpoints: c.endpoints}
+	}
+	if c.limiter != nil {
+		transport = &rateLimitTransport{next: transport, limiter: c.limiter, retryUnavailable: len(c.endpoints) <= 1}
+	}
+	c.api.Transport = transport
+}
+
+// SetRoundTripper plugs a round tripper between the client and the HTTP
+// transport, such as a Cassette. wrap is given the HTTP transport, which it
+// may call or replace. The logging, failover and rate limiting round
+// trippers still apply on top of it.
+func (c *HttpClient) SetRoundTripper(wrap func(next http.RoundTripper) http.RoundTripper) {
+	c.wrap = wrap
+	c.updateTransport()
+}
+
+// SetProxy sends the requests through the proxy at proxyUrl, which may hold
+// credentials. Without proxyUrl, the proxy is read from the HTTPS_PROXY,
+// HTTP_PROXY and NO_PROXY environment variables when fromEnvironment is set.
+func (c *HttpClient) SetProxy(proxyUrl string, fromEnvironment bool) error {
+	if proxyUrl != "" {
+		if !isValidUrl(proxyUrl) {
+			return fmt.Errorf("Invalid proxy url")
+		}
+		parsed, err := url.Parse(proxyUrl)
+		if err != nil {
+			return err
+		}
+		c.transport.Proxy = http.ProxyURL(parsed)
+		return nil
+	}
+	if fromEnvironment {
+		c.transport.Proxy = http.ProxyFromEnvironment
+		return nil
+	}
+	c.transport.Proxy = nil
+	return nil
 }
 
 func (c *HttpClient) Get(url string, headers map[string]string) ([]byte, int, error) {
@@ -56,6 +124,10 @@ func (c *HttpClient) Delete(url string, headers map[string]string) ([]byte, int,
 	return c.Request("DELETE", url, headers)
 }
 
+func (c *HttpClient) DeleteJson(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error) {
+	return c.RequestJson("DELETE", url, headers, jsonBody)
+}
+
 func (c *HttpClient) Put(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error) {
 	return c.RequestJson("PUT", url, headers, jsonBody)
 }
@@ -68,6 +140,83 @@ func (c *HttpClient) Patch(url string, headers map[string]string, jsonBody []byt
 	return c.RequestJson("PATCH", url, headers, jsonBody)
 }
 
+func (c *HttpClient) PostReturnReader(url string, headers map[string]string, jsonBody []byte) (io.ReadCloser, int, error) {
+	if !isValidUrl(url) {
+		return nil, 0, fmt.Errorf("Invalid url  %s", url)
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, 0, err
+	}
+	for key, value := range headers {
+		req.Header.Add(key, value)
+	}
+	resp, err := c.api.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, resp.StatusCode, err
+}
+
+// PostMultipart uploads content as a single file field of a multipart form.
+// The Content-Type header is always set to the multipart boundary, overriding
+// any value passed in headers.
+func (c *HttpClient) PostMultipart(url string, headers map[string]string, fieldName, fileName string, content io.Reader) ([]byte, int, error) {
+	if !isValidUrl(url) {
+		return nil, 0, fmt.Errorf("Invalid url  %s", url)
+	}
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(fieldName, fileName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err = io.Copy(part, content); err != nil {
+		return nil, 0, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		return nil, 0, err
+	}
+	for key, value := range headers {
+		req.Header.Add(key, value)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, err := c.api.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	return respBody, resp.StatusCode, err
+}
+
+// PostBinary sends content as the raw request body with the given Content-Type,
+// overriding any value passed in headers.
+func (c *HttpClient) PostBinary(url string, headers map[string]string, contentType string, content io.Reader) ([]byte, int, error) {
+	if !isValidUrl(url) {
+		return nil, 0, fmt.Errorf("Invalid url  %s", url)
+	}
+	req, err := http.NewRequest("POST", url, content)
+	if err != nil {
+		return nil, 0, err
+	}
+	for key, value := range headers {
+		req.Header.Add(key, value)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := c.api.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	return respBody, resp.StatusCode, err
+}
+
 func (c *HttpClient) Request(method string, url string, headers map[string]string) ([]byte, int, error) {
 	respBodyReader, statusCode, err := c.RequestReader(method, url, headers)
 	if err != nil {
@@ -2,6 +2,7 @@ package httpClient
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -11,6 +12,16 @@ import (
 type HttpClientMock struct {
 	ShouldFail bool
 	Resp       []FakeResponse
+	// Requests are the requests sent to the mock, in order.
+	Requests []FakeRequest
+}
+
+// FakeRequest is a request sent to HttpClientMock. The body of multipart
+// and binary uploads isn't kept.
+type FakeRequest struct {
+	Method string
+	Url    string
+	Body   []byte
 }
 
 type FakeResponse struct {
@@ -20,6 +31,7 @@ type FakeResponse struct {
 }
 
 func (c *HttpClientMock) Get(url string, headers map[string]string) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "GET", Url: url})
 	if c.ShouldFail {
 		return []byte(""), 400, errors.New("Failed to get resource")
 	}
@@ -27,6 +39,7 @@ func (c *HttpClientMock) Get(url string, headers map[string]string) ([]byte, int
 	return resp.Payload, resp.Status, nil
 }
 func (c *HttpClientMock) GetReturnHeaders(url string, headers map[string]string) ([]byte, http.Header, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "GET", Url: url})
 	if c.ShouldFail {
 		return []byte(""), http.Header{}, 400, errors.New("Failed to get resource")
 	}
@@ -35,6 +48,7 @@ func (c *HttpClientMock) GetReturnHeaders(url string, headers map[string]string)
 }
 
 func (c *HttpClientMock) GetReturnReader(url string, headers map[string]string) (io.ReadCloser, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "GET", Url: url})
 	if c.ShouldFail {
 		return nil, 400, errors.New("Failed to get resource")
 	}
@@ -43,6 +57,16 @@ func (c *HttpClientMock) GetReturnReader(url string, headers map[string]string)
 }
 
 func (c *HttpClientMock) Delete(url string, headers map[string]string) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "DELETE", Url: url})
+	if c.ShouldFail {
+		return []byte(""), 400, errors.New("Failed to delete resource")
+	}
+	resp := c.PopPayload()
+	return resp.Payload, resp.Status, nil
+}
+
+func (c *HttpClientMock) DeleteJson(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "DELETE", Url: url, Body: jsonBody})
 	if c.ShouldFail {
 		return []byte(""), 400, errors.New("Failed to delete resource")
 	}
@@ -51,6 +75,7 @@ func (c *HttpClientMock) Delete(url string, headers map[string]string) ([]byte,
 }
 
 func (c *HttpClientMock) Put(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "PUT", Url: url, Body: jsonBody})
 	if c.ShouldFail {
 		return []byte(""), 400, errors.New("Failed to put resource")
 	}
@@ -59,6 +84,7 @@ func (c *HttpClientMock) Put(url string, headers map[string]string, jsonBody []b
 }
 
 func (c *HttpClientMock) Post(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "POST", Url: url, Body: jsonBody})
 	if c.ShouldFail {
 		return []byte(""), 400, errors.New("Failed to get resource")
 	}
@@ -67,6 +93,7 @@ func (c *HttpClientMock) Post(url string, headers map[string]string, jsonBody []
 }
 
 func (c *HttpClientMock) Patch(url string, headers map[string]string, jsonBody []byte) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "PATCH", Url: url, Body: jsonBody})
 	if c.ShouldFail {
 		return []byte(""), 400, errors.New("Failed to get resource")
 	}
@@ -74,6 +101,49 @@ func (c *HttpClientMock) Patch(url string, headers map[string]string, jsonBody [
 	return resp.Payload, resp.Status, nil
 }
 
+func (c *HttpClientMock) PostReturnReader(url string, headers map[string]string, jsonBody []byte) (io.ReadCloser, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "POST", Url: url, Body: jsonBody})
+	if c.ShouldFail {
+		return nil, 400, errors.New("Failed to post resource")
+	}
+	resp := c.PopPayload()
+	return ioutil.NopCloser(bytes.NewReader(resp.Payload)), resp.Status, nil
+}
+
+func (c *HttpClientMock) PostMultipart(url string, headers map[string]string, fieldName, fileName string, content io.Reader) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "POST", Url: url})
+	if c.ShouldFail {
+		return []byte(""), 400, errors.New("Failed to upload resource")
+	}
+	resp := c.PopPayload()
+	return resp.Payload, resp.Status, nil
+}
+
+func (c *HttpClientMock) PostBinary(url string, headers map[string]string, contentType string, content io.Reader) ([]byte, int, error) {
+	c.Requests = append(c.Requests, FakeRequest{Method: "POST", Url: url})
+	if c.ShouldFail {
+		return []byte(""), 400, errors.New("Failed to upload resource")
+	}
+	resp := c.PopPayload()
+	return resp.Payload, resp.Status, nil
+}
+
+func (c *HttpClientMock) SetEndpoints(endpoints []string) error {
+	return nil
+}
+
+func (c *HttpClientMock) SetProxy(proxyUrl string, fromEnvironment bool) error {
+	return nil
+}
+
+func (c *HttpClientMock) EnableLogging(ctx context.Context) {}
+
+func (c *HttpClientMock) SetRateLimit(requestsPerSecond float64, maxConcurrent, maxRetries int) error {
+	return nil
+}
+
+func (c *HttpClientMock) SetRoundTripper(wrap func(next http.RoundTripper) http.RoundTripper) {}
+
 func (c *HttpClientMock) PopPayload() (ret FakeResponse) {
 	if len(c.Resp) > 0 {
 		ret = c.Resp[0]
@@ -0,0 +1,248 @@
+package httpClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redacted = "REDACTED"
+	// maxLoggedBody is the size above which logged bodies are truncated.
+	maxLoggedBody = 64 * 1024
+)
+
+// sensitiveFields are the JSON fields whose value is never logged, such as
+// the secrets of connectors or the SSL key of Fleet outputs.
+var sensitiveFields = map[string]bool{
+	"secrets":         true,
+	"secret":          true,
+	"password":        true,
+	"passphrase":      true,
+	"token":           true,
+	"api_key":         true,
+	"apikey":          true,
+	"key":             true,
+	"private_key":     true,
+	"service_token":   true,
+	"authorization":   true,
+	"access_token":    true,
+	"refresh_token":   true,
+	"client_secret":   true,
+	"secret_key":      true,
+	"secretaccesskey": true,
+}
+
+// sensitiveApiFields are the JSON fields whose value is never logged in the
+// payloads of the APIs whose path contains the key, on top of
+// sensitiveFields, such as the synthetics monitor params which usually hold
+// the credentials used by the monitors.
+var sensitiveApiFields = map[string]map[string]bool{
+	"/api/synthetics/": {"params": true},
+}
+
+// isSensitiveField returns whether the value of the JSON field key is never
+// logged in the payloads of the API at path.
+func isSensitiveField(path, key string) bool {
+	key = strings.ToLower(key)
+	if sensitiveFields[key] {
+		return true
+	}
+	for api, fields := range sensitiveApiFields {
+		if fields[key] && strings.Contains(path, api) {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveHeaders are the headers whose value is never logged, along with
+// the ones whose name looks like a credential.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+func isSensitiveHeader(name string) bool {
+	if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for _, word := range []string{"auth", "token", "secret", "key", "password"} {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// loggingTransport logs the requests and the responses with tflog: the
+// method, the URL, the status and the latency at DEBUG level, the headers
+// and the JSON bodies at TRACE level. Credentials and sensitive fields are
+// redacted.
+type loggingTransport struct {
+	next http.RoundTripper
+	ctx  context.Context
+}
+
+// EnableLogging logs the requests with the logger of ctx, which is the
+// provider logger filtered by TF_LOG_PROVIDER. Since the client methods don't
+// take a context, every request is logged with ctx: the logs are tagged with
+// the tf_rpc and tf_req_id of the call which enabled logging, usually
+// ConfigureProvider, rather than those of the resource operation sending the
+// request.
+func (c *HttpClient) EnableLogging(ctx context.Context) {
+	c.logContext = ctx
+	c.updateTransport()
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    redactUrl(req.URL.String()),
+	}
+	tflog.Debug(t.ctx, "Sending HTTP request", fields)
+	tflog.Trace(t.ctx, "HTTP request", fields, map[string]interface{}{
+		"http_headers": redactHeaders(req.Header),
+		"http_body":    lazyBody(func() string { return requestBody(req) }),
+	})
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.Debug(t.ctx, "HTTP request failed", fields, map[string]interface{}{"error": err.Error()})
+		return resp, err
+	}
+	fields["http_status"] = resp.StatusCode
+	tflog.Debug(t.ctx, "Received HTTP response", fields)
+	tflog.Trace(t.ctx, "HTTP response", fields, map[string]interface{}{
+		"http_headers": redactHeaders(resp.Header),
+		"http_body":    lazyBody(func() string { return responseBody(req.URL.Path, resp) }),
+	})
+	return resp, nil
+}
+
+// lazyBody is a logged body which is only read and redacted when the log
+// line is written, since the logger drops the TRACE lines, and doesn't format
+// their fields, unless trace logging is enabled.
+type lazyBody func() string
+
+func (b lazyBody) String() string {
+	return b()
+}
+
+func (b lazyBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b())
+}
+
+func redactUrl(rawUrl string) string {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || parsed.User == nil {
+		return rawUrl
+	}
+	parsed.User = nil
+	return parsed.String()
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	res := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if isSensitiveHeader(name) {
+			res[name] = redacted
+			continue
+		}
+		res[name] = strings.Join(values, ", ")
+	}
+	return res
+}
+
+// requestBody returns the loggable request body, without consuming it.
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	if !isJsonContent(req.Header.Get("Content-Type")) {
+		return fmt.Sprintf("<%s body>", req.Header.Get("Content-Type"))
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return redactBody(req.URL.Path, content)
+}
+
+// responseBody returns the loggable body of the response of the API at path.
+// JSON bodies are read and replaced with a copy, other bodies are streamed
+// untouched.
+func responseBody(path string, resp *http.Response) string {
+	if resp.Body == nil || !isJsonContent(resp.Header.Get("Content-Type")) {
+		return fmt.Sprintf("<%s body>", resp.Header.Get("Content-Type"))
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(content))
+	if err != nil {
+		return ""
+	}
+	return redactBody(path, content)
+}
+
+func isJsonContent(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// redactBody replaces the value of the sensitive fields of a JSON body sent
+// to or received from path. Bodies which aren't valid JSON are not logged
+// since they can't be redacted.
+func redactBody(path string, content []byte) string {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return ""
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		return fmt.Sprintf("<invalid JSON body of %d bytes>", len(content))
+	}
+	res, err := json.Marshal(redactValue(path, decoded))
+	if err != nil {
+		return ""
+	}
+	if len(res) > maxLoggedBody {
+		return fmt.Sprintf("%s... (truncated from %d bytes)", res[:maxLoggedBody], len(res))
+	}
+	return string(res)
+}
+
+func redactValue(path string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveField(path, key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(path, item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(path, item)
+		}
+	}
+	return value
+}
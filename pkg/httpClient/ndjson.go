@@ -0,0 +1,34 @@
+package httpClient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// maxNdjsonLineSize bounds the size of a single NDJSON document. Saved objects
+// such as dashboards can embed large JSON strings, so it is well above the
+// bufio.Scanner default.
+const maxNdjsonLineSize = 64 * 1024 * 1024
+
+// DecodeNdjson streams newline delimited JSON from reader, calling fn for every
+// non-empty line.
+func DecodeNdjson(reader io.Reader, fn func(line json.RawMessage) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNdjsonLineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		// The scanner reuses its buffer, so the line must be copied before
+		// being handed over.
+		doc := make(json.RawMessage, len(line))
+		copy(doc, line)
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,226 @@
+package httpClient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetryDelay bounds the delay before retrying a request Kibana
+	// rejected because it was overloaded.
+	maxRetryDelay = 30 * time.Second
+	// minBackoffInterval and maxBackoffInterval bound the interval between
+	// requests while Kibana is overloaded.
+	minBackoffInterval = 100 * time.Millisecond
+	maxBackoffInterval = 10 * time.Second
+)
+
+// rateLimiter bounds the rate and the number of concurrent requests of a
+// client, shared by every resource using it. When Kibana answers 429 or 503,
+// every request is paused before the rejected one is retried and the request
+// rate is halved, then recovers as requests succeed.
+type rateLimiter struct {
+	interval    time.Duration
+	burst       int
+	slots       chan struct{}
+	maxRetries  int
+	mutex       sync.Mutex
+	nextRequest time.Time
+	pausedUntil time.Time
+	// backoffInterval is the interval between requests imposed by the
+	// overload of Kibana, zero when it isn't overloaded.
+	backoffInterval time.Duration
+}
+
+// rateLimitTransport retries the requests rejected with 429. It also retries
+// the ones rejected with 503 when retryUnavailable is set, that is unless
+// failoverTransport already sends them to the next endpoint.
+type rateLimitTransport struct {
+	next             http.RoundTripper
+	limiter          *rateLimiter
+	retryUnavailable bool
+}
+
+// SetRateLimit limits the requests to requestsPerSecond, with bursts of up
+// to one second of requests, and to maxConcurrent requests in flight. Zero
+// disables a limit. Requests rejected with 429 are retried up to maxRetries
+// times, honoring Retry-After, as well as the ones rejected with 503 unless
+// several endpoints are set, in which case they fail over instead.
+func (c *HttpClient) SetRateLimit(requestsPerSecond float64, maxConcurrent, maxRetries int) error {
+	if requestsPerSecond < 0 || maxConcurrent < 0 || maxRetries < 0 {
+		return fmt.Errorf("Rate limits can't be negative")
+	}
+	limiter := &rateLimiter{maxRetries: maxRetries}
+	if requestsPerSecond > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+		limiter.burst = int(requestsPerSecond)
+		if limiter.burst < 1 {
+			limiter.burst = 1
+		}
+	}
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+	c.limiter = limiter
+	c.updateTransport()
+	return nil
+}
+
+// reserve takes the next request slot of the rate limit and returns how long
+// to wait before sending the request.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := time.Now()
+	var delay time.Duration
+	interval, burst := l.interval, l.burst
+	if l.backoffInterval > interval {
+		// No bursts while Kibana is overloaded.
+		interval, burst = l.backoffInterval, 1
+	}
+	if interval > 0 {
+		if l.nextRequest.Before(now) {
+			l.nextRequest = now
+		}
+		start := l.nextRequest.Add(-time.Duration(burst-1) * interval)
+		l.nextRequest = l.nextRequest.Add(interval)
+		if start.After(now) {
+			delay = start.Sub(now)
+		}
+	}
+	if l.pausedUntil.After(now.Add(delay)) {
+		delay = l.pausedUntil.Sub(now)
+	}
+	return delay
+}
+
+// throttled delays every request by delay and halves the request rate.
+func (l *rateLimiter) throttled(delay time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if until := time.Now().Add(delay); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	backoff := l.backoffInterval
+	if backoff < l.interval {
+		backoff = l.interval
+	}
+	backoff *= 2
+	if backoff < minBackoffInterval {
+		backoff = minBackoffInterval
+	}
+	if backoff > maxBackoffInterval {
+		backoff = maxBackoffInterval
+	}
+	l.backoffInterval = backoff
+}
+
+// succeeded raises the request rate back by a tenth after a throttling.
+func (l *rateLimiter) succeeded() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.backoffInterval -= l.backoffInterval / 10
+	if l.backoffInterval < minBackoffInterval || l.backoffInterval <= l.interval {
+		l.backoffInterval = 0
+	}
+}
+
+// acquire takes a concurrent request slot, unless ctx is done first.
+func (l *rateLimiter) acquire(ctx context.Context) error {
+	if l.slots == nil {
+		return nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *rateLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	canRetry := req.Body == nil || req.GetBody != nil
+	attempt := req
+	for retry := 0; ; retry++ {
+		if retry > 0 {
+			attempt = req.Clone(req.Context())
+			if req.Body != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attempt.Body = body
+			}
+		}
+		if delay := t.limiter.reserve(); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-req.Context().Done():
+				timer.Stop()
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+		}
+		if err := t.limiter.acquire(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := t.next.RoundTrip(attempt)
+		if err != nil {
+			t.limiter.release()
+			return nil, err
+		}
+		// Kibana may have applied a request answered with 503 by a proxy, only
+		// idempotent ones are sent again.
+		if resp.StatusCode == 429 || (resp.StatusCode == 503 && t.retryUnavailable && isIdempotent(req.Method)) {
+			t.limiter.throttled(retryDelay(resp, retry))
+			if retry < t.limiter.maxRetries && canRetry {
+				resp.Body.Close()
+				t.limiter.release()
+				continue
+			}
+		} else {
+			t.limiter.succeeded()
+		}
+		// The request is in flight until its body is closed.
+		resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.limiter.release}
+		return resp, nil
+	}
+}
+
+// retryDelay returns the Retry-After delay of the response, or an
+// exponential backoff starting at one second.
+func retryDelay(resp *http.Response, retry int) time.Duration {
+	delay := time.Second << uint(retry)
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil {
+		delay = time.Until(date)
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
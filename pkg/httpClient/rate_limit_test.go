@@ -0,0 +1,196 @@
+package httpClient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func okResponse(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("{}")), Request: req}, nil
+}
+
+func newLimiter(t *testing.T, requestsPerSecond float64, maxConcurrent, maxRetries int) *rateLimiter {
+	client := CreateHTTPClient().(*HttpClient)
+	if err := client.SetRateLimit(requestsPerSecond, maxConcurrent, maxRetries); err != nil {
+		t.Fatal(err)
+	}
+	return client.limiter
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := newLimiter(t, 10, 0, 0)
+	for i := 0; i < 10; i++ {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Fatalf("Request %d of the burst delayed by %s", i, delay)
+		}
+	}
+	if delay := limiter.reserve(); delay < 50*time.Millisecond || delay > 100*time.Millisecond {
+		t.Errorf("The request after the burst should wait about 100ms, got %s", delay)
+	}
+}
+
+func TestRateLimiterBackoff(t *testing.T) {
+	limiter := newLimiter(t, 0, 0, 0)
+	if delay := limiter.reserve(); delay != 0 {
+		t.Fatalf("Unlimited requests delayed by %s", delay)
+	}
+	limiter.throttled(0)
+	limiter.throttled(0)
+	if limiter.backoffInterval != 2*minBackoffInterval {
+		t.Fatalf("The interval should double on each throttling, got %s", limiter.backoffInterval)
+	}
+	limiter.reserve()
+	if delay := limiter.reserve(); delay < minBackoffInterval {
+		t.Errorf("Requests should be spaced while throttled, got %s", delay)
+	}
+	for i := 0; i < 10; i++ {
+		limiter.succeeded()
+	}
+	if limiter.backoffInterval != 0 {
+		t.Errorf("The rate should recover as requests succeed, got %s", limiter.backoffInterval)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for _, tc := range []struct {
+		retryAfter string
+		retry      int
+		min, max   time.Duration
+	}{
+		{"", 0, time.Second, time.Second},
+		{"", 2, 4 * time.Second, 4 * time.Second},
+		{"", 10, maxRetryDelay, maxRetryDelay},
+		{"3", 0, 3 * time.Second, 3 * time.Second},
+		{"3600", 0, maxRetryDelay, maxRetryDelay},
+		{time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat), 0, 3 * time.Second, 5 * time.Second},
+	} {
+		resp := &http.Response{Header: http.Header{}}
+		if tc.retryAfter != "" {
+			resp.Header.Set("Retry-After", tc.retryAfter)
+		}
+		if delay := retryDelay(resp, tc.retry); delay < tc.min || delay > tc.max {
+			t.Errorf("Retry-After %q, retry %d: got %s, expected between %s and %s", tc.retryAfter, tc.retry, delay, tc.min, tc.max)
+		}
+	}
+}
+
+func TestRateLimitReleasesSlotOnBodyClose(t *testing.T) {
+	limiter := newLimiter(t, 0, 1, 0)
+	transport := &rateLimitTransport{next: roundTripperFunc(okResponse), limiter: limiter}
+	req, _ := http.NewRequest("GET", "http://kibana.test/api/status", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The only slot is held until the body is closed, a cancelled request
+	// waiting for it gives up.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := transport.RoundTrip(req.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Fatalf("Expected the request to be cancelled, got %v", err)
+	}
+	resp.Body.Close()
+	resp.Body.Close()
+	resp, err = transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestRateLimitConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}))
+	defer server.Close()
+	client := CreateHTTPClient()
+	if err := client.SetRateLimit(0, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, status, err := client.Get(server.URL, nil); err != nil || status != 200 {
+				t.Errorf("GET: %d %v", status, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxInFlight != 2 {
+		t.Errorf("Expected 2 requests in flight at most, got %d", maxInFlight)
+	}
+}
+
+func TestRateLimitRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		method    string
+		status    int
+		endpoints int
+		requests  int32
+	}{
+		{"429 is retried", "GET", 429, 1, 3},
+		{"503 is retried on a single endpoint", "GET", 503, 1, 3},
+		{"429 is retried with several endpoints", "GET", 429, 2, 3},
+		{"503 fails over with several endpoints", "GET", 503, 2, 2},
+		{"429 is retried for POST", "POST", 429, 1, 3},
+		{"503 is not retried for POST", "POST", 503, 1, 1},
+	} {
+		var requests int32
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(tc.status)
+		})
+		endpoints := []string{}
+		for i := 0; i < tc.endpoints; i++ {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+			endpoints = append(endpoints, server.URL)
+		}
+		client := CreateHTTPClient()
+		if err := client.SetRateLimit(0, 0, 2); err != nil {
+			t.Fatal(err)
+		}
+		if err := client.SetEndpoints(endpoints); err != nil {
+			t.Fatal(err)
+		}
+		var status int
+		var err error
+		if tc.method == "POST" {
+			_, status, err = client.Post(endpoints[0]+"/api/alerting/rule", nil, []byte(`{}`))
+		} else {
+			_, status, err = client.Get(endpoints[0]+"/api/status", nil)
+		}
+		if err != nil || status != tc.status {
+			t.Errorf("%s: got %d %v", tc.name, status, err)
+		}
+		if requests != tc.requests {
+			t.Errorf("%s: expected %d requests, got %d", tc.name, tc.requests, requests)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type settingsRequest struct {
+	Changes map[string]json.RawMessage `json:"changes"`
+}
+
+type settingsResponse struct {
+	Settings map[string]struct {
+		UserValue json.RawMessage `json:"userValue"`
+	} `json:"settings"`
+}
+
+func (c *KibanaClient) settingsUrl(spaceId string, global bool) string {
+	if global {
+		return fmt.Sprintf("%s/api/kibana/global_settings", c.host)
+	}
+	return c.spaceUrl(spaceId, "/api/kibana/settings")
+}
+
+// ReadAdvancedSettings returns the advanced settings set by users, leaving out
+// the ones using their default value.
+func (c *KibanaClient) ReadAdvancedSettings(spaceId string, global bool) (map[string]json.RawMessage, error) {
+	var result settingsResponse
+	r, statusCode, err := c.api.Get(c.settingsUrl(spaceId, global), c.requestHeaders())
+	if err != nil {
+		return nil, errors.Wrapf(err, "Reading advanced settings failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return nil, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	if err = json.Unmarshal(r, &result); err != nil {
+		return nil, err
+	}
+	settings := make(map[string]json.RawMessage)
+	for key, setting := range result.Settings {
+		if len(setting.UserValue) == 0 || string(setting.UserValue) == "null" {
+			continue
+		}
+		settings[key] = setting.UserValue
+	}
+	return settings, nil
+}
+
+// UpdateAdvancedSettings applies the given changes. A null value resets the
+// setting to its default.
+func (c *KibanaClient) UpdateAdvancedSettings(spaceId string, global bool, changes map[string]json.RawMessage) error {
+	jsonChanges, err := json.Marshal(settingsRequest{Changes: changes})
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Post(c.settingsUrl(spaceId, global), c.requestHeaders(), jsonChanges)
+	if err != nil {
+		return errors.Wrapf(err, "Updating advanced settings failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonChanges))
+	}
+	return nil
+}
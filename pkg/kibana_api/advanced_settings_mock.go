@@ -0,0 +1,45 @@
+package kibana
+
+import (
+	"encoding/json"
+)
+
+func mockSettingsScope(spaceId string, global bool) string {
+	if global {
+		return "global"
+	}
+	if spaceId == "" {
+		return "default"
+	}
+	return spaceId
+}
+
+func (c *KibanaMockClient) ReadAdvancedSettings(spaceId string, global bool) (map[string]json.RawMessage, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	settings := make(map[string]json.RawMessage)
+	for key, value := range c.settings[mockSettingsScope(spaceId, global)] {
+		settings[key] = value
+	}
+	return settings, nil
+}
+
+func (c *KibanaMockClient) UpdateAdvancedSettings(spaceId string, global bool, changes map[string]json.RawMessage) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.settings == nil {
+		c.settings = make(map[string]map[string]json.RawMessage)
+	}
+	scope := mockSettingsScope(spaceId, global)
+	if c.settings[scope] == nil {
+		c.settings[scope] = make(map[string]json.RawMessage)
+	}
+	for key, value := range changes {
+		if string(value) == "null" {
+			delete(c.settings[scope], key)
+			continue
+		}
+		c.settings[scope][key] = value
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ApmAgentConfiguration is the central configuration of the APM agents of a
+// service. An empty service name or environment applies to all of them.
+type ApmAgentConfiguration struct {
+	Service   ApmService        `json:"service"`
+	Settings  map[string]string `json:"settings"`
+	AgentName string            `json:"agent_name,omitempty"`
+}
+
+type ApmService struct {
+	Name        string `json:"name,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+type apmAgentConfigurationsResponse struct {
+	Configurations []ApmAgentConfiguration `json:"configurations"`
+}
+
+func (c *KibanaClient) PutApmAgentConfiguration(config ApmAgentConfiguration, overwrite bool) error {
+	url := fmt.Sprintf("%s/api/apm/settings/agent-configuration", c.host)
+	if overwrite {
+		url = fmt.Sprintf("%s?overwrite=true", url)
+	}
+	jsonConfig, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonConfig)
+	if err != nil {
+		return errors.Wrapf(err, "Saving APM agent configuration failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonConfig))
+	}
+	return nil
+}
+
+// ReadApmAgentConfiguration lists the configurations and returns the one of
+// the exact service and environment, the search API falls back on the
+// configurations of all services and environments.
+func (c *KibanaClient) ReadApmAgentConfiguration(serviceName, environment string) (ApmAgentConfiguration, error) {
+	url := fmt.Sprintf("%s/api/apm/settings/agent-configuration", c.host)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return ApmAgentConfiguration{}, errors.Wrapf(err, "Reading APM agent configuration failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return ApmAgentConfiguration{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	var result apmAgentConfigurationsResponse
+	if err = json.Unmarshal(r, &result); err != nil {
+		// Kibana versions before 7.10 return a bare list.
+		if err = json.Unmarshal(r, &result.Configurations); err != nil {
+			return ApmAgentConfiguration{}, err
+		}
+	}
+	for _, config := range result.Configurations {
+		if config.Service.Name == serviceName && config.Service.Environment == environment {
+			return config, nil
+		}
+	}
+	return ApmAgentConfiguration{}, errors.Wrapf(ErrNotFound, "APM agent configuration %s", ApmAgentConfigurationId(serviceName, environment))
+}
+
+func (c *KibanaClient) DeleteApmAgentConfiguration(serviceName, environment string) error {
+	url := fmt.Sprintf("%s/api/apm/settings/agent-configuration", c.host)
+	jsonService, err := json.Marshal(map[string]ApmService{"service": {Name: serviceName, Environment: environment}})
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.DeleteJson(url, c.requestHeaders(), jsonService)
+	if err != nil {
+		return errors.Wrapf(err, "Deleting APM agent configuration failed")
+	}
+	if statusCode == 404 {
+		return errors.Wrapf(ErrNotFound, "APM agent configuration %s", ApmAgentConfigurationId(serviceName, environment))
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
+
+// ApmAgentConfigurationId identifies a configuration as
+// <service_name>:<environment>, keeping only the service name when the
+// configuration applies to all environments. * stands for all services.
+func ApmAgentConfigurationId(serviceName, environment string) string {
+	if serviceName == "" {
+		serviceName = "*"
+	}
+	if environment == "" {
+		return serviceName
+	}
+	return fmt.Sprintf("%s:%s", serviceName, environment)
+}
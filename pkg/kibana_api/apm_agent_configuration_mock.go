@@ -0,0 +1,41 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) PutApmAgentConfiguration(config ApmAgentConfiguration, overwrite bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.apmAgentConfigurations == nil {
+		c.apmAgentConfigurations = make(map[ApmService]ApmAgentConfiguration)
+	}
+	if _, ok := c.apmAgentConfigurations[config.Service]; ok && !overwrite {
+		return fmt.Errorf("Saving APM agent configuration failed - configuration already exists")
+	}
+	c.apmAgentConfigurations[config.Service] = config
+	return nil
+}
+
+func (c *KibanaMockClient) ReadApmAgentConfiguration(serviceName, environment string) (ApmAgentConfiguration, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	config, ok := c.apmAgentConfigurations[ApmService{Name: serviceName, Environment: environment}]
+	if !ok {
+		return ApmAgentConfiguration{}, errors.Wrapf(ErrNotFound, "APM agent configuration %s", ApmAgentConfigurationId(serviceName, environment))
+	}
+	return config, nil
+}
+
+func (c *KibanaMockClient) DeleteApmAgentConfiguration(serviceName, environment string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	service := ApmService{Name: serviceName, Environment: environment}
+	if _, ok := c.apmAgentConfigurations[service]; !ok {
+		return errors.Wrapf(ErrNotFound, "APM agent configuration %s", ApmAgentConfigurationId(serviceName, environment))
+	}
+	delete(c.apmAgentConfigurations, service)
+	return nil
+}
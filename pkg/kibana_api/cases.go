@@ -0,0 +1,185 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// CaseConfiguration is the configuration of the cases of an owner (cases,
+// observability or securitySolution) in a space.
+type CaseConfiguration struct {
+	Id          string        `json:"id,omitempty"`
+	Version     string        `json:"version,omitempty"`
+	Owner       string        `json:"owner,omitempty"`
+	Connector   CaseConnector `json:"connector"`
+	ClosureType string        `json:"closure_type"`
+	// CustomFields and Templates require Kibana 8.13 and 8.15, they are only
+	// sent when set, an empty list clearing them.
+	CustomFields *[]CaseCustomField `json:"customFields,omitempty"`
+	Templates    *[]CaseTemplate    `json:"templates,omitempty"`
+}
+
+// CaseConnector is the connector cases are pushed to, .none when cases are
+// not pushed.
+type CaseConnector struct {
+	Id     string          `json:"id"`
+	Name   string          `json:"name"`
+	Type   string          `json:"type"`
+	Fields json.RawMessage `json:"fields"`
+}
+
+type CaseCustomField struct {
+	Key          string          `json:"key"`
+	Label        string          `json:"label"`
+	Type         string          `json:"type"`
+	Required     bool            `json:"required"`
+	DefaultValue json.RawMessage `json:"defaultValue,omitempty"`
+}
+
+type CaseTemplate struct {
+	Key         string          `json:"key"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	CaseFields  json.RawMessage `json:"caseFields,omitempty"`
+}
+
+// NoneCaseConnector is the connector of configurations which don't push
+// cases.
+var NoneCaseConnector = CaseConnector{Id: "none", Name: "none", Type: ".none", Fields: json.RawMessage("null")}
+
+func (c *KibanaClient) ListCaseConfigurations(spaceId string) ([]CaseConfiguration, error) {
+	var result []CaseConfiguration
+	url := c.spaceUrl(spaceId, "/api/cases/configure")
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return nil, errors.Wrapf(err, "Reading case configurations failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return nil, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) CreateCaseConfiguration(spaceId string, config CaseConfiguration) (CaseConfiguration, error) {
+	var result CaseConfiguration
+	url := c.spaceUrl(spaceId, "/api/cases/configure")
+	config.Id = ""
+	config.Version = ""
+	jsonConfig, err := json.Marshal(config)
+	if err != nil {
+		return CaseConfiguration{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonConfig)
+	if err != nil {
+		return CaseConfiguration{}, errors.Wrapf(err, "Creating case configuration failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return CaseConfiguration{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonConfig))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+// UpdateCaseConfiguration updates a configuration, config.Version must be the
+// version of the configuration in Kibana.
+func (c *KibanaClient) UpdateCaseConfiguration(spaceId, configurationId string, config CaseConfiguration) (CaseConfiguration, error) {
+	var result CaseConfiguration
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/cases/configure/%s", configurationId))
+	// The owner of a configuration can't be changed
+	config.Id = ""
+	config.Owner = ""
+	jsonConfig, err := json.Marshal(config)
+	if err != nil {
+		return CaseConfiguration{}, err
+	}
+	r, statusCode, err := c.api.Patch(url, c.requestHeaders(), jsonConfig)
+	if err != nil {
+		return CaseConfiguration{}, errors.Wrapf(err, "Updating case configuration failed")
+	}
+	if statusCode == 404 {
+		return CaseConfiguration{}, errors.Wrapf(ErrNotFound, "Case configuration %s", configurationId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return CaseConfiguration{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonConfig))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+// Case is a case, as returned by the find API.
+type Case struct {
+	Id              string               `json:"id"`
+	Title           string               `json:"title"`
+	Description     string               `json:"description"`
+	Status          string               `json:"status"`
+	Severity        string               `json:"severity"`
+	Tags            []string             `json:"tags"`
+	Owner           string               `json:"owner"`
+	CreatedAt       string               `json:"created_at"`
+	CreatedBy       CaseUser             `json:"created_by"`
+	TotalComment    int                  `json:"totalComment"`
+	ExternalService *CaseExternalService `json:"external_service"`
+}
+
+type CaseUser struct {
+	Username string `json:"username"`
+}
+
+type CaseExternalService struct {
+	ExternalId    string `json:"external_id"`
+	ExternalTitle string `json:"external_title"`
+	ExternalUrl   string `json:"external_url"`
+}
+
+type CaseFilter struct {
+	Status string
+	Owner  string
+	Tags   []string
+}
+
+type casesFindResponse struct {
+	Cases []Case `json:"cases"`
+	Total int    `json:"total"`
+}
+
+const casesPerPage = 100
+
+func (c *KibanaClient) FindCases(spaceId string, filter CaseFilter) ([]Case, error) {
+	cases := []Case{}
+	for page := 1; ; page++ {
+		var result casesFindResponse
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("perPage", strconv.Itoa(casesPerPage))
+		if filter.Status != "" {
+			query.Set("status", filter.Status)
+		}
+		if filter.Owner != "" {
+			query.Set("owner", filter.Owner)
+		}
+		for _, tag := range filter.Tags {
+			query.Add("tags", tag)
+		}
+		url := c.spaceUrl(spaceId, fmt.Sprintf("/api/cases/_find?%s", query.Encode()))
+		r, statusCode, err := c.api.Get(url, c.requestHeaders())
+		if err != nil {
+			return nil, errors.Wrapf(err, "Finding cases failed")
+		}
+		if statusCode != 200 && statusCode != 204 {
+			return nil, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+		}
+		if err = json.Unmarshal(r, &result); err != nil {
+			return nil, err
+		}
+		cases = append(cases, result.Cases...)
+		if len(result.Cases) == 0 || len(cases) >= result.Total {
+			return cases, nil
+		}
+	}
+}
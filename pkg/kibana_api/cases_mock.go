@@ -0,0 +1,81 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) ListCaseConfigurations(spaceId string) ([]CaseConfiguration, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	configs := []CaseConfiguration{}
+	for _, config := range c.caseConfigurations {
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+func (c *KibanaMockClient) CreateCaseConfiguration(spaceId string, config CaseConfiguration) (CaseConfiguration, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.caseConfigurations == nil {
+		c.caseConfigurations = make(map[string]CaseConfiguration)
+	}
+	for id, existing := range c.caseConfigurations {
+		if existing.Owner == config.Owner {
+			delete(c.caseConfigurations, id)
+		}
+	}
+	config.Id = c.newId()
+	config.Version = c.newId()
+	c.caseConfigurations[config.Id] = config
+	return config, nil
+}
+
+func (c *KibanaMockClient) UpdateCaseConfiguration(spaceId, configurationId string, config CaseConfiguration) (CaseConfiguration, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing, ok := c.caseConfigurations[configurationId]
+	if !ok {
+		return CaseConfiguration{}, errors.Wrapf(ErrNotFound, "Case configuration %s", configurationId)
+	}
+	if existing.Version != config.Version {
+		return CaseConfiguration{}, fmt.Errorf("Failed updating case configuration - version conflict")
+	}
+	config.Id = configurationId
+	config.Owner = existing.Owner
+	config.Version = c.newId()
+	c.caseConfigurations[configurationId] = config
+	return config, nil
+}
+
+func (c *KibanaMockClient) FindCases(spaceId string, filter CaseFilter) ([]Case, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cases := []Case{}
+	for _, kibanaCase := range c.Cases {
+		if filter.Status != "" && kibanaCase.Status != filter.Status {
+			continue
+		}
+		if filter.Owner != "" && kibanaCase.Owner != filter.Owner {
+			continue
+		}
+		if len(filter.Tags) > 0 && !caseHasAnyTag(kibanaCase, filter.Tags) {
+			continue
+		}
+		cases = append(cases, kibanaCase)
+	}
+	return cases, nil
+}
+
+func caseHasAnyTag(kibanaCase Case, tags []string) bool {
+	for _, tag := range tags {
+		for _, caseTag := range kibanaCase.Tags {
+			if tag == caseTag {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package kibana
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCaseConfigurationOmitsUnsetLists(t *testing.T) {
+	cleared := []CaseCustomField{}
+	for _, tc := range []struct {
+		config CaseConfiguration
+		want   string
+	}{
+		{CaseConfiguration{Connector: NoneCaseConnector, ClosureType: "close-by-user"}, `{"connector":{"id":"none","name":"none","type":".none","fields":null},"closure_type":"close-by-user"}`},
+		{CaseConfiguration{Connector: NoneCaseConnector, ClosureType: "close-by-user", CustomFields: &cleared}, `{"connector":{"id":"none","name":"none","type":".none","fields":null},"closure_type":"close-by-user","customFields":[]}`},
+	} {
+		got, err := json.Marshal(tc.config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("Configuration marshalled as %s, expected %s", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package kibana
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// KibanaUrlFromCloudId decodes the Kibana endpoint of an Elastic Cloud
+// deployment from its cloud ID. A cloud ID is the deployment name followed
+// by the base64 encoding of host$elasticsearch_id$kibana_id, where host may
+// end with a port.
+func KibanaUrlFromCloudId(cloudId string) (string, error) {
+	parts := strings.SplitN(cloudId, ":", 2)
+	encoded := parts[len(parts)-1]
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(encoded, "="))
+		if err != nil {
+			return "", fmt.Errorf("Invalid cloud ID: %s", err)
+		}
+	}
+	ids := strings.Split(string(decoded), "$")
+	if len(ids) < 3 || ids[0] == "" || ids[2] == "" {
+		return "", fmt.Errorf("Invalid cloud ID: no Kibana endpoint")
+	}
+	host := ids[0]
+	port := ""
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host, port = host[:i], host[i+1:]
+	}
+	kibanaId := ids[2]
+	// The Kibana ID may carry its own port as id:port.
+	if i := strings.LastIndex(kibanaId, ":"); i >= 0 {
+		kibanaId, port = kibanaId[:i], kibanaId[i+1:]
+	}
+	if port == "" || port == "443" {
+		return fmt.Sprintf("https://%s.%s", kibanaId, host), nil
+	}
+	return fmt.Sprintf("https://%s.%s:%s", kibanaId, host, port), nil
+}
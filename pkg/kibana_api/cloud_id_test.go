@@ -0,0 +1,40 @@
+package kibana
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestKibanaUrlFromCloudId(t *testing.T) {
+	encode := func(ids string) string {
+		return base64.StdEncoding.EncodeToString([]byte(ids))
+	}
+	for _, tc := range []struct {
+		cloudId string
+		url     string
+	}{
+		{"deployment:" + encode("eu-west-1.aws.found.io$es0123$kb4567"), "https://kb4567.eu-west-1.aws.found.io"},
+		{encode("eu-west-1.aws.found.io$es0123$kb4567"), "https://kb4567.eu-west-1.aws.found.io"},
+		{"deployment:" + encode("eu-west-1.aws.found.io:9243$es0123$kb4567"), "https://kb4567.eu-west-1.aws.found.io:9243"},
+		{"deployment:" + encode("eu-west-1.aws.found.io:443$es0123$kb4567"), "https://kb4567.eu-west-1.aws.found.io"},
+		{"deployment:" + encode("eu-west-1.aws.found.io:9243$es0123$kb4567:9244"), "https://kb4567.eu-west-1.aws.found.io:9244"},
+		// Cloud IDs are sometimes copied without their base64 padding.
+		{"deployment:" + strings.TrimRight(encode("us-east-1.aws.found.io$es$kb1"), "="), "https://kb1.us-east-1.aws.found.io"},
+	} {
+		url, err := KibanaUrlFromCloudId(tc.cloudId)
+		if err != nil || url != tc.url {
+			t.Errorf("%s: got %q %v, expected %q", tc.cloudId, url, err, tc.url)
+		}
+	}
+	for _, cloudId := range []string{
+		"deployment:not base64!",
+		"deployment:" + encode("eu-west-1.aws.found.io$es0123"),
+		"deployment:" + encode("eu-west-1.aws.found.io$es0123$"),
+		"deployment:" + encode("$es0123$kb4567"),
+	} {
+		if url, err := KibanaUrlFromCloudId(cloudId); err == nil {
+			t.Errorf("%s: expected an error, got %q", cloudId, url)
+		}
+	}
+}
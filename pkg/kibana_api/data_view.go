@@ -0,0 +1,107 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type DataView struct {
+	Id              string          `json:"id,omitempty"`
+	Title           string          `json:"title,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	TimeFieldName   string          `json:"timeFieldName,omitempty"`
+	SourceFilters   []SourceFilter  `json:"sourceFilters"`
+	FieldFormats    json.RawMessage `json:"fieldFormats,omitempty"`
+	RuntimeFieldMap json.RawMessage `json:"runtimeFieldMap,omitempty"`
+	FieldAttrs      json.RawMessage `json:"fieldAttrs,omitempty"`
+	AllowNoIndex    bool            `json:"allowNoIndex,omitempty"`
+	Namespaces      []string        `json:"namespaces,omitempty"`
+}
+
+type SourceFilter struct {
+	Value string `json:"value"`
+}
+
+// dataViewApi returns the path and the body key of the data view API. Before
+// 8.0 data views were index patterns, managed with the same payload.
+func (c *KibanaClient) dataViewApi() (path, key string) {
+	if !c.version.AtLeast(8, 0) {
+		return "/api/index_patterns/index_pattern", "index_pattern"
+	}
+	return "/api/data_views/data_view", "data_view"
+}
+
+func (c *KibanaClient) CreateDataView(spaceId string, dataView DataView) (DataView, error) {
+	var result map[string]DataView
+	path, key := c.dataViewApi()
+	url := c.spaceUrl(spaceId, path)
+	jsonDataView, err := json.Marshal(map[string]DataView{key: dataView})
+	if err != nil {
+		return DataView{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonDataView)
+	if err != nil {
+		return DataView{}, errors.Wrapf(err, "Creating data view failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return DataView{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonDataView))
+	}
+	err = json.Unmarshal(r, &result)
+	return result[key], err
+}
+
+func (c *KibanaClient) ReadDataView(spaceId, dataViewId string) (DataView, error) {
+	var result map[string]DataView
+	path, key := c.dataViewApi()
+	url := c.spaceUrl(spaceId, fmt.Sprintf("%s/%s", path, dataViewId))
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return DataView{}, errors.Wrapf(err, "Reading data view failed")
+	}
+	if statusCode == 404 {
+		return DataView{}, errors.Wrapf(ErrNotFound, "Data view %s", dataViewId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return DataView{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result[key], err
+}
+
+func (c *KibanaClient) UpdateDataView(spaceId, dataViewId string, dataView DataView) (DataView, error) {
+	var result map[string]DataView
+	path, key := c.dataViewApi()
+	url := c.spaceUrl(spaceId, fmt.Sprintf("%s/%s", path, dataViewId))
+	// The id and the namespaces of a data view can't be changed once created
+	// and Kibana rejects update requests containing them.
+	dataView.Id = ""
+	dataView.Namespaces = nil
+	jsonDataView, err := json.Marshal(map[string]DataView{key: dataView})
+	if err != nil {
+		return DataView{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonDataView)
+	if err != nil {
+		return DataView{}, errors.Wrapf(err, "Updating data view failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return DataView{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonDataView))
+	}
+	err = json.Unmarshal(r, &result)
+	return result[key], err
+}
+
+func (c *KibanaClient) DeleteDataView(spaceId, dataViewId string) error {
+	path, _ := c.dataViewApi()
+	url := c.spaceUrl(spaceId, fmt.Sprintf("%s/%s", path, dataViewId))
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting data view failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateDataView(spaceId string, dataView DataView) (DataView, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.dataViews == nil {
+		c.dataViews = make(map[string]DataView)
+	}
+	if dataView.Id == "" {
+		dataView.Id = c.newId()
+	}
+	if _, ok := c.dataViews[dataView.Id]; ok {
+		return DataView{}, fmt.Errorf("Creating data view failed - duplicate id")
+	}
+	c.dataViews[dataView.Id] = dataView
+	return dataView, nil
+}
+
+func (c *KibanaMockClient) ReadDataView(spaceId, dataViewId string) (DataView, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	dataView, ok := c.dataViews[dataViewId]
+	if !ok {
+		return DataView{}, errors.Wrapf(ErrNotFound, "Data view %s", dataViewId)
+	}
+	return dataView, nil
+}
+
+func (c *KibanaMockClient) UpdateDataView(spaceId, dataViewId string, dataView DataView) (DataView, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing, ok := c.dataViews[dataViewId]
+	if !ok {
+		return DataView{}, fmt.Errorf("Failed updating data view - unknown data view id")
+	}
+	dataView.Id = dataViewId
+	dataView.Namespaces = existing.Namespaces
+	c.dataViews[dataViewId] = dataView
+	return dataView, nil
+}
+
+func (c *KibanaMockClient) DeleteDataView(spaceId, dataViewId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.dataViews[dataViewId]; !ok {
+		return fmt.Errorf("Deleting data view failed - unknown id")
+	}
+	delete(c.dataViews, dataViewId)
+	return nil
+}
@@ -0,0 +1,102 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// FleetAgentPolicy output and Fleet Server host ids are sent as null when
+// unset, so that removing them resets the policy to the defaults.
+// IsProtected requires Kibana 8.10, it is only sent when set.
+type FleetAgentPolicy struct {
+	Id                 string   `json:"id,omitempty"`
+	Name               string   `json:"name"`
+	Namespace          string   `json:"namespace"`
+	Description        string   `json:"description,omitempty"`
+	MonitoringEnabled  []string `json:"monitoring_enabled"`
+	DataOutputId       *string  `json:"data_output_id"`
+	MonitoringOutputId *string  `json:"monitoring_output_id"`
+	FleetServerHostId  *string  `json:"fleet_server_host_id"`
+	InactivityTimeout  int      `json:"inactivity_timeout,omitempty"`
+	IsProtected        *bool    `json:"is_protected,omitempty"`
+}
+
+type fleetAgentPolicyResponse struct {
+	Item FleetAgentPolicy `json:"item"`
+}
+
+type fleetAgentPolicyDeleteRequest struct {
+	AgentPolicyId string `json:"agentPolicyId"`
+}
+
+func (c *KibanaClient) CreateFleetAgentPolicy(policy FleetAgentPolicy) (FleetAgentPolicy, error) {
+	var result fleetAgentPolicyResponse
+	url := fmt.Sprintf("%s/api/fleet/agent_policies", c.host)
+	jsonPolicy, err := json.Marshal(policy)
+	if err != nil {
+		return FleetAgentPolicy{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonPolicy)
+	if err != nil {
+		return FleetAgentPolicy{}, errors.Wrapf(err, "Creating agent policy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetAgentPolicy{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonPolicy))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) ReadFleetAgentPolicy(policyId string) (FleetAgentPolicy, error) {
+	var result fleetAgentPolicyResponse
+	url := fmt.Sprintf("%s/api/fleet/agent_policies/%s", c.host, policyId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return FleetAgentPolicy{}, errors.Wrapf(err, "Reading agent policy failed")
+	}
+	if statusCode == 404 {
+		return FleetAgentPolicy{}, errors.Wrapf(ErrNotFound, "Agent policy %s", policyId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetAgentPolicy{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) UpdateFleetAgentPolicy(policyId string, policy FleetAgentPolicy) (FleetAgentPolicy, error) {
+	var result fleetAgentPolicyResponse
+	url := fmt.Sprintf("%s/api/fleet/agent_policies/%s", c.host, policyId)
+	policy.Id = ""
+	jsonPolicy, err := json.Marshal(policy)
+	if err != nil {
+		return FleetAgentPolicy{}, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonPolicy)
+	if err != nil {
+		return FleetAgentPolicy{}, errors.Wrapf(err, "Updating agent policy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetAgentPolicy{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonPolicy))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) DeleteFleetAgentPolicy(policyId string) error {
+	url := fmt.Sprintf("%s/api/fleet/agent_policies/delete", c.host)
+	jsonRequest, err := json.Marshal(fleetAgentPolicyDeleteRequest{AgentPolicyId: policyId})
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonRequest)
+	if err != nil {
+		return errors.Wrapf(err, "Deleting agent policy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
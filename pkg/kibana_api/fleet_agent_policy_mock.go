@@ -0,0 +1,51 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateFleetAgentPolicy(policy FleetAgentPolicy) (FleetAgentPolicy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.agentPolicies == nil {
+		c.agentPolicies = make(map[string]FleetAgentPolicy)
+	}
+	if policy.Id == "" {
+		policy.Id = c.newId()
+	}
+	c.agentPolicies[policy.Id] = policy
+	return policy, nil
+}
+
+func (c *KibanaMockClient) ReadFleetAgentPolicy(policyId string) (FleetAgentPolicy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	policy, ok := c.agentPolicies[policyId]
+	if !ok {
+		return FleetAgentPolicy{}, errors.Wrapf(ErrNotFound, "Agent policy %s", policyId)
+	}
+	return policy, nil
+}
+
+func (c *KibanaMockClient) UpdateFleetAgentPolicy(policyId string, policy FleetAgentPolicy) (FleetAgentPolicy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.agentPolicies[policyId]; !ok {
+		return FleetAgentPolicy{}, fmt.Errorf("Failed updating agent policy - unknown id")
+	}
+	policy.Id = policyId
+	c.agentPolicies[policyId] = policy
+	return policy, nil
+}
+
+func (c *KibanaMockClient) DeleteFleetAgentPolicy(policyId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.agentPolicies[policyId]; !ok {
+		return fmt.Errorf("Deleting agent policy failed - unknown id")
+	}
+	delete(c.agentPolicies, policyId)
+	return nil
+}
@@ -0,0 +1,29 @@
+package kibana
+
+import (
+	"testing"
+
+	myhttp "github.com/qonto/terraform-provider-kibana/pkg/httpClient"
+)
+
+func TestUpdateFleetAgentPolicySendsUnsetIdsAsNull(t *testing.T) {
+	mock := &myhttp.HttpClientMock{Resp: []myhttp.FakeResponse{{Status: 200, Payload: []byte(`{"item":{"id":"p1"}}`)}}}
+	client := &KibanaClient{api: mock, host: "http://kibana:5601"}
+	output := "o1"
+	_, err := client.UpdateFleetAgentPolicy("p1", FleetAgentPolicy{
+		Name:              "Servers",
+		Namespace:         "default",
+		MonitoringEnabled: []string{},
+		DataOutputId:      &output,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.Requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(mock.Requests))
+	}
+	want := `{"name":"Servers","namespace":"default","monitoring_enabled":[],"data_output_id":"o1","monitoring_output_id":null,"fleet_server_host_id":null}`
+	if got := string(mock.Requests[0].Body); got != want {
+		t.Errorf("Request body %s, expected %s", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FleetEnrollmentToken is an enrollment API key, used by agents to enroll in
+// an agent policy.
+type FleetEnrollmentToken struct {
+	Id        string `json:"id,omitempty"`
+	ApiKeyId  string `json:"api_key_id,omitempty"`
+	ApiKey    string `json:"api_key,omitempty"`
+	Name      string `json:"name,omitempty"`
+	PolicyId  string `json:"policy_id"`
+	Active    bool   `json:"active,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type fleetEnrollmentTokenResponse struct {
+	Item FleetEnrollmentToken `json:"item"`
+}
+
+type fleetEnrollmentTokensResponse struct {
+	Items []FleetEnrollmentToken `json:"items"`
+	// List is the name of the items before Kibana 8.0.
+	List  []FleetEnrollmentToken `json:"list"`
+	Total int                    `json:"total"`
+}
+
+const fleetEnrollmentTokensPerPage = 100
+
+// ListFleetEnrollmentTokens returns the enrollment tokens of an agent policy,
+// or every enrollment token when policyId is empty.
+func (c *KibanaClient) ListFleetEnrollmentTokens(policyId string) ([]FleetEnrollmentToken, error) {
+	tokens := []FleetEnrollmentToken{}
+	for page := 1; ; page++ {
+		var result fleetEnrollmentTokensResponse
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("perPage", strconv.Itoa(fleetEnrollmentTokensPerPage))
+		if policyId != "" {
+			query.Set("kuery", fmt.Sprintf("policy_id:%s", kueryQuote(policyId)))
+		}
+		url := fmt.Sprintf("%s/api/fleet/enrollment_api_keys?%s", c.host, query.Encode())
+		r, statusCode, err := c.api.Get(url, c.requestHeaders())
+		if err != nil {
+			return nil, errors.Wrapf(err, "Listing enrollment tokens failed")
+		}
+		if statusCode != 200 && statusCode != 204 {
+			return nil, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+		}
+		if err = json.Unmarshal(r, &result); err != nil {
+			return nil, err
+		}
+		items := result.Items
+		if items == nil {
+			items = result.List
+		}
+		tokens = append(tokens, items...)
+		if len(items) == 0 || len(tokens) >= result.Total {
+			return tokens, nil
+		}
+	}
+}
+
+// kueryQuote quotes a value for a KQL query, escaping the backslashes and
+// double quotes it contains.
+func kueryQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func (c *KibanaClient) CreateFleetEnrollmentToken(token FleetEnrollmentToken) (FleetEnrollmentToken, error) {
+	var result fleetEnrollmentTokenResponse
+	url := fmt.Sprintf("%s/api/fleet/enrollment_api_keys", c.host)
+	jsonToken, err := json.Marshal(token)
+	if err != nil {
+		return FleetEnrollmentToken{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonToken)
+	if err != nil {
+		return FleetEnrollmentToken{}, errors.Wrapf(err, "Creating enrollment token failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetEnrollmentToken{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonToken))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) ReadFleetEnrollmentToken(tokenId string) (FleetEnrollmentToken, error) {
+	var result fleetEnrollmentTokenResponse
+	url := fmt.Sprintf("%s/api/fleet/enrollment_api_keys/%s", c.host, tokenId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return FleetEnrollmentToken{}, errors.Wrapf(err, "Reading enrollment token failed")
+	}
+	if statusCode == 404 {
+		return FleetEnrollmentToken{}, errors.Wrapf(ErrNotFound, "Enrollment token %s", tokenId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetEnrollmentToken{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+// RevokeFleetEnrollmentToken deactivates an enrollment token. Revoked tokens
+// are still listed by Kibana, with active set to false.
+func (c *KibanaClient) RevokeFleetEnrollmentToken(tokenId string) error {
+	url := fmt.Sprintf("%s/api/fleet/enrollment_api_keys/%s", c.host, tokenId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Revoking enrollment token failed")
+	}
+	if statusCode == 404 {
+		return errors.Wrapf(ErrNotFound, "Enrollment token %s", tokenId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
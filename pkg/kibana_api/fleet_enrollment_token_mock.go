@@ -0,0 +1,62 @@
+package kibana
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) ListFleetEnrollmentTokens(policyId string) ([]FleetEnrollmentToken, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tokens := []FleetEnrollmentToken{}
+	for _, token := range c.enrollmentTokens {
+		if policyId == "" || token.PolicyId == policyId {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (c *KibanaMockClient) CreateFleetEnrollmentToken(token FleetEnrollmentToken) (FleetEnrollmentToken, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.enrollmentTokens == nil {
+		c.enrollmentTokens = make(map[string]FleetEnrollmentToken)
+	}
+	token.Id = c.newId()
+	token.ApiKeyId = c.newId()
+	token.ApiKey = c.newId()
+	if token.Name == "" {
+		token.Name = c.newId()
+	} else {
+		token.Name = fmt.Sprintf("%s (%s)", token.Name, c.newId())
+	}
+	token.Active = true
+	token.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	c.enrollmentTokens[token.Id] = token
+	return token, nil
+}
+
+func (c *KibanaMockClient) ReadFleetEnrollmentToken(tokenId string) (FleetEnrollmentToken, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	token, ok := c.enrollmentTokens[tokenId]
+	if !ok {
+		return FleetEnrollmentToken{}, errors.Wrapf(ErrNotFound, "Enrollment token %s", tokenId)
+	}
+	return token, nil
+}
+
+func (c *KibanaMockClient) RevokeFleetEnrollmentToken(tokenId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	token, ok := c.enrollmentTokens[tokenId]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "Enrollment token %s", tokenId)
+	}
+	token.Active = false
+	c.enrollmentTokens[tokenId] = token
+	return nil
+}
@@ -0,0 +1,32 @@
+package kibana
+
+import (
+	"net/url"
+	"testing"
+
+	myhttp "github.com/qonto/terraform-provider-kibana/pkg/httpClient"
+)
+
+func TestListFleetEnrollmentTokensEscapesPolicyId(t *testing.T) {
+	for _, tc := range []struct {
+		policyId string
+		kuery    string
+	}{
+		{"p1", `policy_id:"p1"`},
+		{`p" or policy_id:"p2`, `policy_id:"p\" or policy_id:\"p2"`},
+		{`p\`, `policy_id:"p\\"`},
+	} {
+		mock := &myhttp.HttpClientMock{Resp: []myhttp.FakeResponse{{Status: 200, Payload: []byte(`{"items":[],"total":0}`)}}}
+		client := &KibanaClient{api: mock, host: "http://kibana:5601"}
+		if _, err := client.ListFleetEnrollmentTokens(tc.policyId); err != nil {
+			t.Fatal(err)
+		}
+		requestUrl, err := url.Parse(mock.Requests[0].Url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kuery := requestUrl.Query().Get("kuery"); kuery != tc.kuery {
+			t.Errorf("Policy %q queried with %s, expected %s", tc.policyId, kuery, tc.kuery)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// FleetOutput optional settings are sent as null when unset, Fleet keeps the
+// omitted ones on update.
+type FleetOutput struct {
+	Id                   string          `json:"id,omitempty"`
+	Name                 string          `json:"name"`
+	Type                 string          `json:"type"`
+	Hosts                []string        `json:"hosts"`
+	IsDefault            bool            `json:"is_default"`
+	IsDefaultMonitoring  bool            `json:"is_default_monitoring"`
+	CaSha256             *string         `json:"ca_sha256"`
+	CaTrustedFingerprint *string         `json:"ca_trusted_fingerprint"`
+	ConfigYaml           *string         `json:"config_yaml"`
+	ProxyId              *string         `json:"proxy_id"`
+	Ssl                  *FleetOutputSsl `json:"ssl"`
+	// Kafka outputs only.
+	ClientId     string `json:"client_id,omitempty"`
+	Version      string `json:"version,omitempty"`
+	AuthType     string `json:"auth_type,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+	Topic        string `json:"topic,omitempty"`
+	Compression  string `json:"compression,omitempty"`
+	Partition    string `json:"partition,omitempty"`
+	RequiredAcks int    `json:"required_acks,omitempty"`
+}
+
+type FleetOutputSsl struct {
+	CertificateAuthorities []string `json:"certificate_authorities,omitempty"`
+	Certificate            string   `json:"certificate,omitempty"`
+	Key                    string   `json:"key,omitempty"`
+}
+
+type fleetOutputResponse struct {
+	Item FleetOutput `json:"item"`
+}
+
+func (c *KibanaClient) CreateFleetOutput(output FleetOutput) (FleetOutput, error) {
+	var result fleetOutputResponse
+	url := fmt.Sprintf("%s/api/fleet/outputs", c.host)
+	jsonOutput, err := json.Marshal(output)
+	if err != nil {
+		return FleetOutput{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonOutput)
+	if err != nil {
+		return FleetOutput{}, errors.Wrapf(err, "Creating output failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		// The request body is left out of the error as it may hold secrets.
+		return FleetOutput{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) ReadFleetOutput(outputId string) (FleetOutput, error) {
+	var result fleetOutputResponse
+	url := fmt.Sprintf("%s/api/fleet/outputs/%s", c.host, outputId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return FleetOutput{}, errors.Wrapf(err, "Reading output failed")
+	}
+	if statusCode == 404 {
+		return FleetOutput{}, errors.Wrapf(ErrNotFound, "Output %s", outputId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetOutput{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) UpdateFleetOutput(outputId string, output FleetOutput) (FleetOutput, error) {
+	var result fleetOutputResponse
+	url := fmt.Sprintf("%s/api/fleet/outputs/%s", c.host, outputId)
+	output.Id = ""
+	jsonOutput, err := json.Marshal(output)
+	if err != nil {
+		return FleetOutput{}, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonOutput)
+	if err != nil {
+		return FleetOutput{}, errors.Wrapf(err, "Updating output failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetOutput{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) DeleteFleetOutput(outputId string) error {
+	url := fmt.Sprintf("%s/api/fleet/outputs/%s", c.host, outputId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting output failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
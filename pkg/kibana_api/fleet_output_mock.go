@@ -0,0 +1,56 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateFleetOutput(output FleetOutput) (FleetOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.outputs == nil {
+		c.outputs = make(map[string]FleetOutput)
+	}
+	if output.Id == "" {
+		output.Id = c.newId()
+	}
+	c.outputs[output.Id] = output
+	return output, nil
+}
+
+func (c *KibanaMockClient) ReadFleetOutput(outputId string) (FleetOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	output, ok := c.outputs[outputId]
+	if !ok {
+		return FleetOutput{}, errors.Wrapf(ErrNotFound, "Output %s", outputId)
+	}
+	return output, nil
+}
+
+func (c *KibanaMockClient) UpdateFleetOutput(outputId string, output FleetOutput) (FleetOutput, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing, ok := c.outputs[outputId]
+	if !ok {
+		return FleetOutput{}, fmt.Errorf("Failed updating output - unknown id")
+	}
+	var merged FleetOutput
+	if err := mergeUpdate(existing, output, &merged); err != nil {
+		return FleetOutput{}, err
+	}
+	merged.Id = outputId
+	c.outputs[outputId] = merged
+	return merged, nil
+}
+
+func (c *KibanaMockClient) DeleteFleetOutput(outputId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.outputs[outputId]; !ok {
+		return fmt.Errorf("Deleting output failed - unknown id")
+	}
+	delete(c.outputs, outputId)
+	return nil
+}
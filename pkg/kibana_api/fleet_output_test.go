@@ -0,0 +1,52 @@
+package kibana
+
+import (
+	"testing"
+
+	myhttp "github.com/qonto/terraform-provider-kibana/pkg/httpClient"
+)
+
+func TestUpdateFleetOutputSendsUnsetSettingsAsNull(t *testing.T) {
+	mock := &myhttp.HttpClientMock{Resp: []myhttp.FakeResponse{{Status: 200, Payload: []byte(`{"item":{"id":"o1"}}`)}}}
+	client := &KibanaClient{api: mock, host: "http://kibana:5601"}
+	_, err := client.UpdateFleetOutput("o1", FleetOutput{Name: "Logstash", Type: "logstash", Hosts: []string{"logstash:5044"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"Logstash","type":"logstash","hosts":["logstash:5044"],"is_default":false,"is_default_monitoring":false,"ca_sha256":null,"ca_trusted_fingerprint":null,"config_yaml":null,"proxy_id":null,"ssl":null}`
+	if got := string(mock.Requests[0].Body); got != want {
+		t.Errorf("Request body %s, expected %s", got, want)
+	}
+}
+
+func TestUpdateFleetServerHostSendsUnsetProxyAsNull(t *testing.T) {
+	mock := &myhttp.HttpClientMock{Resp: []myhttp.FakeResponse{{Status: 200, Payload: []byte(`{"item":{"id":"h1"}}`)}}}
+	client := &KibanaClient{api: mock, host: "http://kibana:5601"}
+	_, err := client.UpdateFleetServerHost("h1", FleetServerHost{Name: "Fleet Server", HostUrls: []string{"https://fleet:8220"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"Fleet Server","host_urls":["https://fleet:8220"],"is_default":false,"proxy_id":null}`
+	if got := string(mock.Requests[0].Body); got != want {
+		t.Errorf("Request body %s, expected %s", got, want)
+	}
+}
+
+func TestMockFleetOutputUpdateClearsSettings(t *testing.T) {
+	client := &KibanaMockClient{}
+	proxy := "p1"
+	created, err := client.CreateFleetOutput(FleetOutput{Name: "Logstash", Type: "logstash", ProxyId: &proxy, Ssl: &FleetOutputSsl{Certificate: "cert"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.UpdateFleetOutput(created.Id, FleetOutput{Name: "Logstash", Type: "logstash"}); err != nil {
+		t.Fatal(err)
+	}
+	read, err := client.ReadFleetOutput(created.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.ProxyId != nil || read.Ssl != nil {
+		t.Errorf("Proxy %v and SSL %v kept after being cleared", read.ProxyId, read.Ssl)
+	}
+}
@@ -0,0 +1,121 @@
+package kibana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type FleetPackage struct {
+	Name             string
+	Version          string
+	Status           string
+	InstalledVersion string
+}
+
+type fleetPackageInstallRequest struct {
+	Force             bool `json:"force,omitempty"`
+	IgnoreConstraints bool `json:"ignore_constraints,omitempty"`
+}
+
+type fleetPackageInfo struct {
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	Status           string `json:"status"`
+	InstallationInfo *struct {
+		Version string `json:"version"`
+	} `json:"installationInfo,omitempty"`
+	SavedObject *struct {
+		Attributes struct {
+			Version string `json:"version"`
+		} `json:"attributes"`
+	} `json:"savedObject,omitempty"`
+}
+
+type fleetPackageResponse struct {
+	Item fleetPackageInfo `json:"item"`
+	// Kibana versions before 8.0 wrap the package in response.
+	Response *fleetPackageInfo `json:"response,omitempty"`
+}
+
+// InstallFleetPackage installs the given version of a package from the package
+// registry, upgrading it when another version is installed.
+func (c *KibanaClient) InstallFleetPackage(name, version string, force, ignoreConstraints bool) error {
+	url := fmt.Sprintf("%s/api/fleet/epm/packages/%s/%s", c.host, name, version)
+	jsonRequest, err := json.Marshal(fleetPackageInstallRequest{Force: force, IgnoreConstraints: ignoreConstraints})
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonRequest)
+	if err != nil {
+		return errors.Wrapf(err, "Installing package failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonRequest))
+	}
+	return nil
+}
+
+// UploadFleetPackage installs a package from a zip archive.
+func (c *KibanaClient) UploadFleetPackage(archive []byte) error {
+	url := fmt.Sprintf("%s/api/fleet/epm/packages", c.host)
+	r, statusCode, err := c.api.PostBinary(url, c.requestHeaders(), "application/zip", bytes.NewReader(archive))
+	if err != nil {
+		return errors.Wrapf(err, "Uploading package failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
+
+// ReadFleetPackage reads a package and its installation status. The latest
+// version is read when version is empty.
+func (c *KibanaClient) ReadFleetPackage(name, version string) (FleetPackage, error) {
+	var result fleetPackageResponse
+	url := fmt.Sprintf("%s/api/fleet/epm/packages/%s", c.host, name)
+	if version != "" {
+		url = fmt.Sprintf("%s/%s", url, version)
+	}
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return FleetPackage{}, errors.Wrapf(err, "Reading package failed")
+	}
+	if statusCode == 404 {
+		return FleetPackage{}, errors.Wrapf(ErrNotFound, "Package %s-%s", name, version)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetPackage{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	if err = json.Unmarshal(r, &result); err != nil {
+		return FleetPackage{}, err
+	}
+	info := result.Item
+	if result.Response != nil {
+		info = *result.Response
+	}
+	fleetPackage := FleetPackage{Name: info.Name, Version: info.Version, Status: info.Status}
+	if info.InstallationInfo != nil {
+		fleetPackage.InstalledVersion = info.InstallationInfo.Version
+	} else if info.SavedObject != nil {
+		fleetPackage.InstalledVersion = info.SavedObject.Attributes.Version
+	}
+	return fleetPackage, nil
+}
+
+func (c *KibanaClient) UninstallFleetPackage(name, version string, force bool) error {
+	url := fmt.Sprintf("%s/api/fleet/epm/packages/%s/%s", c.host, name, version)
+	if force {
+		url = fmt.Sprintf("%s?force=true", url)
+	}
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Uninstalling package failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
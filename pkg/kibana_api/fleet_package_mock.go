@@ -0,0 +1,74 @@
+package kibana
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var mockManifestField = regexp.MustCompile(`(?m)^(name|version):\s*"?([^"\s]+)"?\s*$`)
+
+func (c *KibanaMockClient) InstallFleetPackage(name, version string, force, ignoreConstraints bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.packages == nil {
+		c.packages = make(map[string]string)
+	}
+	c.packages[name] = version
+	return nil
+}
+
+// UploadFleetPackage installs the package described by the manifest.yml of the
+// archive.
+func (c *KibanaMockClient) UploadFleetPackage(archive []byte) error {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return errors.Wrapf(err, "Uploading package failed")
+	}
+	for _, file := range reader.File {
+		if path.Base(file.Name) != "manifest.yml" || strings.Count(strings.Trim(file.Name, "/"), "/") != 1 {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return err
+		}
+		manifest := &bytes.Buffer{}
+		_, err = manifest.ReadFrom(content)
+		content.Close()
+		if err != nil {
+			return err
+		}
+		fields := make(map[string]string)
+		for _, match := range mockManifestField.FindAllStringSubmatch(manifest.String(), -1) {
+			fields[match[1]] = match[2]
+		}
+		return c.InstallFleetPackage(fields["name"], fields["version"], false, false)
+	}
+	return fmt.Errorf("Uploading package failed - manifest.yml not found")
+}
+
+func (c *KibanaMockClient) ReadFleetPackage(name, version string) (FleetPackage, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	installedVersion, ok := c.packages[name]
+	if !ok {
+		return FleetPackage{Name: name, Version: version, Status: "not_installed"}, nil
+	}
+	return FleetPackage{Name: name, Version: version, Status: "installed", InstalledVersion: installedVersion}, nil
+}
+
+func (c *KibanaMockClient) UninstallFleetPackage(name, version string, force bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if installedVersion, ok := c.packages[name]; !ok || installedVersion != version {
+		return fmt.Errorf("Uninstalling package failed - %s-%s is not installed", name, version)
+	}
+	delete(c.packages, name)
+	return nil
+}
@@ -0,0 +1,145 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// FleetPackagePolicy is an integration policy in the simplified format, where
+// inputs and streams are keyed by their identifier.
+type FleetPackagePolicy struct {
+	Id          string                             `json:"id,omitempty"`
+	Name        string                             `json:"name"`
+	Namespace   string                             `json:"namespace,omitempty"`
+	Description string                             `json:"description,omitempty"`
+	PolicyId    string                             `json:"policy_id"`
+	Package     FleetPackageReference              `json:"package"`
+	Vars        map[string]json.RawMessage         `json:"vars,omitempty"`
+	Inputs      map[string]FleetPackagePolicyInput `json:"inputs,omitempty"`
+}
+
+type FleetPackageReference struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type FleetPackagePolicyInput struct {
+	Enabled bool                                `json:"enabled"`
+	Vars    map[string]json.RawMessage          `json:"vars,omitempty"`
+	Streams map[string]FleetPackagePolicyStream `json:"streams,omitempty"`
+}
+
+type FleetPackagePolicyStream struct {
+	Enabled bool                       `json:"enabled"`
+	Vars    map[string]json.RawMessage `json:"vars,omitempty"`
+}
+
+type fleetPackagePolicyResponse struct {
+	Item FleetPackagePolicy `json:"item"`
+}
+
+type fleetPackagePolicyUpgradeRequest struct {
+	PackagePolicyIds []string `json:"packagePolicyIds"`
+}
+
+type fleetPackagePolicyUpgradeResult struct {
+	Id      string          `json:"id"`
+	Success bool            `json:"success"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+func (c *KibanaClient) CreateFleetPackagePolicy(policy FleetPackagePolicy) (FleetPackagePolicy, error) {
+	var result fleetPackagePolicyResponse
+	url := fmt.Sprintf("%s/api/fleet/package_policies?format=simplified", c.host)
+	jsonPolicy, err := json.Marshal(policy)
+	if err != nil {
+		return FleetPackagePolicy{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonPolicy)
+	if err != nil {
+		return FleetPackagePolicy{}, errors.Wrapf(err, "Creating package policy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetPackagePolicy{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) ReadFleetPackagePolicy(policyId string) (FleetPackagePolicy, error) {
+	var result fleetPackagePolicyResponse
+	url := fmt.Sprintf("%s/api/fleet/package_policies/%s?format=simplified", c.host, policyId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return FleetPackagePolicy{}, errors.Wrapf(err, "Reading package policy failed")
+	}
+	if statusCode == 404 {
+		return FleetPackagePolicy{}, errors.Wrapf(ErrNotFound, "Package policy %s", policyId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetPackagePolicy{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) UpdateFleetPackagePolicy(policyId string, policy FleetPackagePolicy) (FleetPackagePolicy, error) {
+	var result fleetPackagePolicyResponse
+	url := fmt.Sprintf("%s/api/fleet/package_policies/%s?format=simplified", c.host, policyId)
+	policy.Id = ""
+	jsonPolicy, err := json.Marshal(policy)
+	if err != nil {
+		return FleetPackagePolicy{}, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonPolicy)
+	if err != nil {
+		return FleetPackagePolicy{}, errors.Wrapf(err, "Updating package policy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		// The request body is left out of the error as it may hold secret vars.
+		return FleetPackagePolicy{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+// UpgradeFleetPackagePolicy upgrades the package policy to the version of the
+// package currently installed, migrating its inputs and vars.
+func (c *KibanaClient) UpgradeFleetPackagePolicy(policyId string) error {
+	var results []fleetPackagePolicyUpgradeResult
+	url := fmt.Sprintf("%s/api/fleet/package_policies/upgrade", c.host)
+	jsonRequest, err := json.Marshal(fleetPackagePolicyUpgradeRequest{PackagePolicyIds: []string{policyId}})
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonRequest)
+	if err != nil {
+		return errors.Wrapf(err, "Upgrading package policy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	if err = json.Unmarshal(r, &results); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if !result.Success {
+			return fmt.Errorf("Upgrading package policy %s failed: %s", result.Id, string(result.Body))
+		}
+	}
+	return nil
+}
+
+func (c *KibanaClient) DeleteFleetPackagePolicy(policyId string) error {
+	url := fmt.Sprintf("%s/api/fleet/package_policies/%s", c.host, policyId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting package policy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
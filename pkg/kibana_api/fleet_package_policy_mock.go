@@ -0,0 +1,73 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateFleetPackagePolicy(policy FleetPackagePolicy) (FleetPackagePolicy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.packagePolicies == nil {
+		c.packagePolicies = make(map[string]FleetPackagePolicy)
+	}
+	if policy.Id == "" {
+		policy.Id = c.newId()
+	}
+	c.packagePolicies[policy.Id] = policy
+	return policy, nil
+}
+
+func (c *KibanaMockClient) ReadFleetPackagePolicy(policyId string) (FleetPackagePolicy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	policy, ok := c.packagePolicies[policyId]
+	if !ok {
+		return FleetPackagePolicy{}, errors.Wrapf(ErrNotFound, "Package policy %s", policyId)
+	}
+	return policy, nil
+}
+
+func (c *KibanaMockClient) UpdateFleetPackagePolicy(policyId string, policy FleetPackagePolicy) (FleetPackagePolicy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing, ok := c.packagePolicies[policyId]
+	if !ok {
+		return FleetPackagePolicy{}, fmt.Errorf("Failed updating package policy - unknown id")
+	}
+	if existing.Package.Version != policy.Package.Version {
+		return FleetPackagePolicy{}, fmt.Errorf("Failed updating package policy - package version changed without upgrade")
+	}
+	policy.Id = policyId
+	c.packagePolicies[policyId] = policy
+	return policy, nil
+}
+
+// UpgradeFleetPackagePolicy moves the package policy to the installed version
+// of its package.
+func (c *KibanaMockClient) UpgradeFleetPackagePolicy(policyId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	policy, ok := c.packagePolicies[policyId]
+	if !ok {
+		return fmt.Errorf("Upgrading package policy failed - unknown id")
+	}
+	installedVersion, ok := c.packages[policy.Package.Name]
+	if !ok {
+		return fmt.Errorf("Upgrading package policy failed - package %s is not installed", policy.Package.Name)
+	}
+	policy.Package.Version = installedVersion
+	c.packagePolicies[policyId] = policy
+	return nil
+}
+
+func (c *KibanaMockClient) DeleteFleetPackagePolicy(policyId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.packagePolicies[policyId]; !ok {
+		return fmt.Errorf("Deleting package policy failed - unknown id")
+	}
+	delete(c.packagePolicies, policyId)
+	return nil
+}
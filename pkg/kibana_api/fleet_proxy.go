@@ -0,0 +1,90 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type FleetProxy struct {
+	Id                     string            `json:"id,omitempty"`
+	Name                   string            `json:"name"`
+	Url                    string            `json:"url"`
+	ProxyHeaders           map[string]string `json:"proxy_headers,omitempty"`
+	CertificateAuthorities string            `json:"certificate_authorities,omitempty"`
+	Certificate            string            `json:"certificate,omitempty"`
+	CertificateKey         string            `json:"certificate_key,omitempty"`
+}
+
+type fleetProxyResponse struct {
+	Item FleetProxy `json:"item"`
+}
+
+func (c *KibanaClient) CreateFleetProxy(proxy FleetProxy) (FleetProxy, error) {
+	var result fleetProxyResponse
+	url := fmt.Sprintf("%s/api/fleet/proxies", c.host)
+	jsonProxy, err := json.Marshal(proxy)
+	if err != nil {
+		return FleetProxy{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonProxy)
+	if err != nil {
+		return FleetProxy{}, errors.Wrapf(err, "Creating proxy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		// The request body is left out of the error as it may hold the
+		// certificate key.
+		return FleetProxy{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) ReadFleetProxy(proxyId string) (FleetProxy, error) {
+	var result fleetProxyResponse
+	url := fmt.Sprintf("%s/api/fleet/proxies/%s", c.host, proxyId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return FleetProxy{}, errors.Wrapf(err, "Reading proxy failed")
+	}
+	if statusCode == 404 {
+		return FleetProxy{}, errors.Wrapf(ErrNotFound, "Proxy %s", proxyId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetProxy{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) UpdateFleetProxy(proxyId string, proxy FleetProxy) (FleetProxy, error) {
+	var result fleetProxyResponse
+	url := fmt.Sprintf("%s/api/fleet/proxies/%s", c.host, proxyId)
+	proxy.Id = ""
+	jsonProxy, err := json.Marshal(proxy)
+	if err != nil {
+		return FleetProxy{}, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonProxy)
+	if err != nil {
+		return FleetProxy{}, errors.Wrapf(err, "Updating proxy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetProxy{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) DeleteFleetProxy(proxyId string) error {
+	url := fmt.Sprintf("%s/api/fleet/proxies/%s", c.host, proxyId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting proxy failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateFleetProxy(proxy FleetProxy) (FleetProxy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.proxies == nil {
+		c.proxies = make(map[string]FleetProxy)
+	}
+	if proxy.Id == "" {
+		proxy.Id = c.newId()
+	}
+	c.proxies[proxy.Id] = proxy
+	return proxy, nil
+}
+
+func (c *KibanaMockClient) ReadFleetProxy(proxyId string) (FleetProxy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	proxy, ok := c.proxies[proxyId]
+	if !ok {
+		return FleetProxy{}, errors.Wrapf(ErrNotFound, "Proxy %s", proxyId)
+	}
+	return proxy, nil
+}
+
+func (c *KibanaMockClient) UpdateFleetProxy(proxyId string, proxy FleetProxy) (FleetProxy, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.proxies[proxyId]; !ok {
+		return FleetProxy{}, fmt.Errorf("Failed updating proxy - unknown id")
+	}
+	proxy.Id = proxyId
+	c.proxies[proxyId] = proxy
+	return proxy, nil
+}
+
+func (c *KibanaMockClient) DeleteFleetProxy(proxyId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.proxies[proxyId]; !ok {
+		return fmt.Errorf("Deleting proxy failed - unknown id")
+	}
+	delete(c.proxies, proxyId)
+	return nil
+}
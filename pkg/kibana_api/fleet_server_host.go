@@ -0,0 +1,88 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// FleetServerHost proxy is sent as null when unset, since Fleet keeps the
+// omitted fields on update.
+type FleetServerHost struct {
+	Id        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	HostUrls  []string `json:"host_urls"`
+	IsDefault bool     `json:"is_default"`
+	ProxyId   *string  `json:"proxy_id"`
+}
+
+type fleetServerHostResponse struct {
+	Item FleetServerHost `json:"item"`
+}
+
+func (c *KibanaClient) CreateFleetServerHost(host FleetServerHost) (FleetServerHost, error) {
+	var result fleetServerHostResponse
+	url := fmt.Sprintf("%s/api/fleet/fleet_server_hosts", c.host)
+	jsonHost, err := json.Marshal(host)
+	if err != nil {
+		return FleetServerHost{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonHost)
+	if err != nil {
+		return FleetServerHost{}, errors.Wrapf(err, "Creating Fleet Server host failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetServerHost{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonHost))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) ReadFleetServerHost(hostId string) (FleetServerHost, error) {
+	var result fleetServerHostResponse
+	url := fmt.Sprintf("%s/api/fleet/fleet_server_hosts/%s", c.host, hostId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return FleetServerHost{}, errors.Wrapf(err, "Reading Fleet Server host failed")
+	}
+	if statusCode == 404 {
+		return FleetServerHost{}, errors.Wrapf(ErrNotFound, "Fleet Server host %s", hostId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetServerHost{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) UpdateFleetServerHost(hostId string, host FleetServerHost) (FleetServerHost, error) {
+	var result fleetServerHostResponse
+	url := fmt.Sprintf("%s/api/fleet/fleet_server_hosts/%s", c.host, hostId)
+	host.Id = ""
+	jsonHost, err := json.Marshal(host)
+	if err != nil {
+		return FleetServerHost{}, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonHost)
+	if err != nil {
+		return FleetServerHost{}, errors.Wrapf(err, "Updating Fleet Server host failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return FleetServerHost{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonHost))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Item, err
+}
+
+func (c *KibanaClient) DeleteFleetServerHost(hostId string) error {
+	url := fmt.Sprintf("%s/api/fleet/fleet_server_hosts/%s", c.host, hostId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting Fleet Server host failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateFleetServerHost(host FleetServerHost) (FleetServerHost, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.serverHosts == nil {
+		c.serverHosts = make(map[string]FleetServerHost)
+	}
+	if host.Id == "" {
+		host.Id = c.newId()
+	}
+	c.serverHosts[host.Id] = host
+	return host, nil
+}
+
+func (c *KibanaMockClient) ReadFleetServerHost(hostId string) (FleetServerHost, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	host, ok := c.serverHosts[hostId]
+	if !ok {
+		return FleetServerHost{}, errors.Wrapf(ErrNotFound, "Fleet Server host %s", hostId)
+	}
+	return host, nil
+}
+
+func (c *KibanaMockClient) UpdateFleetServerHost(hostId string, host FleetServerHost) (FleetServerHost, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing, ok := c.serverHosts[hostId]
+	if !ok {
+		return FleetServerHost{}, fmt.Errorf("Failed updating Fleet Server host - unknown id")
+	}
+	var merged FleetServerHost
+	if err := mergeUpdate(existing, host, &merged); err != nil {
+		return FleetServerHost{}, err
+	}
+	merged.Id = hostId
+	c.serverHosts[hostId] = merged
+	return merged, nil
+}
+
+func (c *KibanaMockClient) DeleteFleetServerHost(hostId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.serverHosts[hostId]; !ok {
+		return fmt.Errorf("Deleting Fleet Server host failed - unknown id")
+	}
+	delete(c.serverHosts, hostId)
+	return nil
+}
@@ -1,8 +1,12 @@
 package kibana
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	myhttp "github.com/qonto/terraform-provider-kibana/pkg/httpClient"
@@ -10,18 +14,99 @@ import (
 
 type KibanaAPI interface {
 	SetupClient(kibana_host, kibana_auth string)
+	SetEndpoints(endpoints []string) error
+	SetProxy(proxyUrl string, fromEnvironment bool) error
+	SetHeaders(headers map[string]string) error
+	EnableLogging(ctx context.Context)
+	SetRateLimit(requestsPerSecond float64, maxConcurrent, maxRetries int) error
+	ReadStatus() (KibanaStatus, error)
+	DetectVersion() (KibanaVersion, error)
+	Version() KibanaVersion
 	CreateAlertRule(alert Alert) (alertId string, err error)
 	DeleteAlertRule(alertId string) error
 	UpdateAlertRule(alertId string, alert Alert) error
 	ReadAlertRule(alertId string) (Alert, error)
 	DisableRule(alertId string) error
 	EnableRule(alertId string) error
+	CreateDataView(spaceId string, dataView DataView) (DataView, error)
+	ReadDataView(spaceId, dataViewId string) (DataView, error)
+	UpdateDataView(spaceId, dataViewId string, dataView DataView) (DataView, error)
+	DeleteDataView(spaceId, dataViewId string) error
+	ImportSavedObjects(spaceId string, ndjson []byte, overwrite, createNewCopies bool) ([]SavedObjectImportResult, error)
+	ExportSavedObjects(spaceId string, objects []SavedObjectId) ([]SavedObject, error)
+	CreateSavedObject(spaceId string, object SavedObject) (SavedObject, error)
+	ReadSavedObject(spaceId, objectType, objectId string) (SavedObject, error)
+	UpdateSavedObject(spaceId string, object SavedObject) (SavedObject, error)
+	DeleteSavedObject(spaceId, objectType, objectId string) error
+	PutRole(role Role) error
+	ReadRole(name string) (Role, error)
+	DeleteRole(name string) error
+	ReadAdvancedSettings(spaceId string, global bool) (map[string]json.RawMessage, error)
+	UpdateAdvancedSettings(spaceId string, global bool, changes map[string]json.RawMessage) error
+	CreateFleetAgentPolicy(policy FleetAgentPolicy) (FleetAgentPolicy, error)
+	ReadFleetAgentPolicy(policyId string) (FleetAgentPolicy, error)
+	UpdateFleetAgentPolicy(policyId string, policy FleetAgentPolicy) (FleetAgentPolicy, error)
+	DeleteFleetAgentPolicy(policyId string) error
+	CreateFleetPackagePolicy(policy FleetPackagePolicy) (FleetPackagePolicy, error)
+	ReadFleetPackagePolicy(policyId string) (FleetPackagePolicy, error)
+	UpdateFleetPackagePolicy(policyId string, policy FleetPackagePolicy) (FleetPackagePolicy, error)
+	UpgradeFleetPackagePolicy(policyId string) error
+	DeleteFleetPackagePolicy(policyId string) error
+	InstallFleetPackage(name, version string, force, ignoreConstraints bool) error
+	UploadFleetPackage(archive []byte) error
+	ReadFleetPackage(name, version string) (FleetPackage, error)
+	UninstallFleetPackage(name, version string, force bool) error
+	CreateFleetOutput(output FleetOutput) (FleetOutput, error)
+	ReadFleetOutput(outputId string) (FleetOutput, error)
+	UpdateFleetOutput(outputId string, output FleetOutput) (FleetOutput, error)
+	DeleteFleetOutput(outputId string) error
+	CreateFleetServerHost(host FleetServerHost) (FleetServerHost, error)
+	ReadFleetServerHost(hostId string) (FleetServerHost, error)
+	UpdateFleetServerHost(hostId string, host FleetServerHost) (FleetServerHost, error)
+	DeleteFleetServerHost(hostId string) error
+	CreateFleetProxy(proxy FleetProxy) (FleetProxy, error)
+	ReadFleetProxy(proxyId string) (FleetProxy, error)
+	UpdateFleetProxy(proxyId string, proxy FleetProxy) (FleetProxy, error)
+	DeleteFleetProxy(proxyId string) error
+	ListFleetEnrollmentTokens(policyId string) ([]FleetEnrollmentToken, error)
+	CreateFleetEnrollmentToken(token FleetEnrollmentToken) (FleetEnrollmentToken, error)
+	ReadFleetEnrollmentToken(tokenId string) (FleetEnrollmentToken, error)
+	RevokeFleetEnrollmentToken(tokenId string) error
+	CreateSyntheticsMonitor(spaceId string, monitor SyntheticsMonitor) (SyntheticsMonitor, error)
+	ReadSyntheticsMonitor(spaceId, monitorId string) (SyntheticsMonitor, error)
+	UpdateSyntheticsMonitor(spaceId, monitorId string, monitor SyntheticsMonitor) (SyntheticsMonitor, error)
+	DeleteSyntheticsMonitor(spaceId, monitorId string) error
+	CreateSyntheticsPrivateLocation(location SyntheticsPrivateLocation) (SyntheticsPrivateLocation, error)
+	ReadSyntheticsPrivateLocation(locationId string) (SyntheticsPrivateLocation, error)
+	DeleteSyntheticsPrivateLocation(locationId string) error
+	PutApmAgentConfiguration(config ApmAgentConfiguration, overwrite bool) error
+	ReadApmAgentConfiguration(serviceName, environment string) (ApmAgentConfiguration, error)
+	DeleteApmAgentConfiguration(serviceName, environment string) error
+	ListCaseConfigurations(spaceId string) ([]CaseConfiguration, error)
+	CreateCaseConfiguration(spaceId string, config CaseConfiguration) (CaseConfiguration, error)
+	UpdateCaseConfiguration(spaceId, configurationId string, config CaseConfiguration) (CaseConfiguration, error)
+	FindCases(spaceId string, filter CaseFilter) ([]Case, error)
+	PutLogstashPipeline(pipeline LogstashPipeline) error
+	ReadLogstashPipeline(pipelineId string) (LogstashPipeline, error)
+	DeleteLogstashPipeline(pipelineId string) error
+	CreateSlo(spaceId string, slo Slo) (Slo, error)
+	ReadSlo(spaceId, sloId string) (Slo, error)
+	UpdateSlo(spaceId, sloId string, slo Slo) (Slo, error)
+	DeleteSlo(spaceId, sloId string) error
 }
 
+// ErrNotFound is returned when the requested object does not exist in Kibana.
+var ErrNotFound = errors.New("Object not found")
+
+// KibanaClient is safe for concurrent use once set up. The headers are
+// replaced rather than modified, so that requests in flight keep a
+// consistent copy.
 type KibanaClient struct {
-	api     myhttp.HttpClientAPI
-	headers map[string]string
-	host    string
+	api          myhttp.HttpClientAPI
+	headersMutex sync.RWMutex
+	headers      map[string]string
+	host         string
+	version      KibanaVersion
 }
 
 type Alert struct {
@@ -38,9 +123,18 @@ type Alert struct {
 }
 
 type Action struct {
-	Id     string          `json:"id"`
-	Group  string          `json:"group"`
-	Params json.RawMessage `json:"params"`
+	Id        string           `json:"id"`
+	Group     string           `json:"group"`
+	Params    json.RawMessage  `json:"params"`
+	Frequency *ActionFrequency `json:"frequency,omitempty"`
+}
+
+// ActionFrequency sets when an action runs, in place of the notify_when and
+// throttle of the rule. Supported since Kibana 8.6.
+type ActionFrequency struct {
+	Summary    bool    `json:"summary"`
+	NotifyWhen string  `json:"notify_when"`
+	Throttle   *string `json:"throttle"`
 }
 type FindResult struct {
 	Alerts []Alert `json:"data"`
@@ -59,10 +153,78 @@ func (c *KibanaClient) SetupClient(kibana_host, kibana_auth string) {
 	headers["Authorization"] = fmt.Sprintf("Basic %s", kibana_auth)
 	headers["Content-Type"] = "application/json"
 	headers["kbn-xsrf"] = "terraform"
+	c.headersMutex.Lock()
 	c.headers = headers
+	c.headersMutex.Unlock()
 	c.host = kibana_host
 }
 
+// requestHeaders returns the headers of the requests, which must not be
+// modified.
+func (c *KibanaClient) requestHeaders() map[string]string {
+	c.headersMutex.RLock()
+	defer c.headersMutex.RUnlock()
+	return c.headers
+}
+
+// SetEndpoints addresses Kibana through several endpoints, failing over to the
+// next one when an endpoint is down.
+func (c *KibanaClient) SetEndpoints(endpoints []string) error {
+	if len(endpoints) == 0 {
+		return fmt.Errorf("No Kibana endpoint")
+	}
+	if err := c.api.SetEndpoints(endpoints); err != nil {
+		return err
+	}
+	c.host = strings.TrimSuffix(endpoints[0], "/")
+	return nil
+}
+
+// SetProxy sends the requests through a proxy, see httpClient.SetProxy.
+func (c *KibanaClient) SetProxy(proxyUrl string, fromEnvironment bool) error {
+	return c.api.SetProxy(proxyUrl, fromEnvironment)
+}
+
+// EnableLogging logs the requests and the responses with the tflog logger of
+// ctx, see httpClient.EnableLogging.
+func (c *KibanaClient) EnableLogging(ctx context.Context) {
+	c.api.EnableLogging(ctx)
+}
+
+// SetRateLimit bounds the requests sent to Kibana, see
+// httpClient.SetRateLimit.
+func (c *KibanaClient) SetRateLimit(requestsPerSecond float64, maxConcurrent, maxRetries int) error {
+	return c.api.SetRateLimit(requestsPerSecond, maxConcurrent, maxRetries)
+}
+
+// SetHeaders adds headers to every request. The headers set by the client,
+// such as Authorization and kbn-xsrf, can't be overridden.
+func (c *KibanaClient) SetHeaders(headers map[string]string) error {
+	c.headersMutex.Lock()
+	defer c.headersMutex.Unlock()
+	merged := make(map[string]string, len(c.headers)+len(headers))
+	for name, value := range c.headers {
+		merged[name] = value
+	}
+	for name, value := range headers {
+		if IsReservedHeader(name) {
+			return fmt.Errorf("Header %s is set by the provider and can't be overridden", name)
+		}
+		merged[name] = value
+	}
+	c.headers = merged
+	return nil
+}
+
+// IsReservedHeader reports whether the header is set by the client.
+func IsReservedHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Content-Type", "Kbn-Xsrf":
+		return true
+	}
+	return false
+}
+
 func (c *KibanaClient) CreateAlertRule(alert Alert) (alertId string, err error) {
 	var result struct {
 		Id string `json:"id"`
@@ -73,7 +235,7 @@ func (c *KibanaClient) CreateAlertRule(alert Alert) (alertId string, err error)
 	if err != nil {
 		return "", err
 	}
-	r, statusCode, err := c.api.Post(url, c.headers, jsonAlert)
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonAlert)
 	if err != nil {
 		return result.Id, errors.Wrapf(err, "Creating rule failed")
 	}
@@ -86,7 +248,7 @@ func (c *KibanaClient) CreateAlertRule(alert Alert) (alertId string, err error)
 
 func (c *KibanaClient) DeleteAlertRule(alertId string) error {
 	url := fmt.Sprintf("%s/api/alerting/rule/%s", c.host, alertId)
-	r, statusCode, err := c.api.Delete(url, c.headers)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
 	if err != nil {
 		return errors.Wrapf(err, "Deleting rule failed")
 	}
@@ -102,7 +264,7 @@ func (c *KibanaClient) UpdateAlertRule(alertId string, alert Alert) error {
 	if err != nil {
 		return err
 	}
-	r, statusCode, err := c.api.Put(url, c.headers, jsonAlert)
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonAlert)
 	if err != nil {
 		return errors.Wrapf(err, "Updating rule failed")
 	}
@@ -115,10 +277,13 @@ func (c *KibanaClient) UpdateAlertRule(alertId string, alert Alert) error {
 func (c *KibanaClient) ReadAlertRule(alertId string) (Alert, error) {
 	var alert Alert
 	url := fmt.Sprintf("%s/api/alerting/rule/%s", c.host, alertId)
-	r, statusCode, err := c.api.Get(url, c.headers)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
 	if err != nil {
 		return alert, errors.Wrapf(err, "Reading rule failed")
 	}
+	if statusCode == 404 {
+		return alert, errors.Wrapf(ErrNotFound, "Rule %s", alertId)
+	}
 	if statusCode != 200 && statusCode != 204 {
 		return alert, fmt.Errorf("Received status %d: %s", statusCode, string(r))
 	}
@@ -128,7 +293,7 @@ func (c *KibanaClient) ReadAlertRule(alertId string) (Alert, error) {
 
 func (c *KibanaClient) EnableRule(alertId string) error {
 	url := fmt.Sprintf("%s/api/alerting/rule/%s/_enable", c.host, alertId)
-	r, statusCode, err := c.api.Post(url, c.headers, []byte{})
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), []byte{})
 	if err != nil {
 		return errors.Wrapf(err, "Enabling rule failed")
 	}
@@ -140,7 +305,7 @@ func (c *KibanaClient) EnableRule(alertId string) error {
 
 func (c *KibanaClient) DisableRule(alertId string) error {
 	url := fmt.Sprintf("%s/api/alerting/rule/%s/_disable", c.host, alertId)
-	r, statusCode, err := c.api.Post(url, c.headers, []byte{})
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), []byte{})
 	if err != nil {
 		return errors.Wrapf(err, "Disabling rule failed")
 	}
@@ -149,3 +314,12 @@ func (c *KibanaClient) DisableRule(alertId string) error {
 	}
 	return nil
 }
+
+// spaceUrl builds the URL of an API path in the given space. The default
+// space is addressed without the /s/{space_id} prefix.
+func (c *KibanaClient) spaceUrl(spaceId, path string) string {
+	if spaceId == "" || spaceId == "default" {
+		return fmt.Sprintf("%s%s", c.host, path)
+	}
+	return fmt.Sprintf("%s/s/%s%s", c.host, spaceId, path)
+}
@@ -0,0 +1,143 @@
+package kibana
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	myhttp "github.com/qonto/terraform-provider-kibana/pkg/httpClient"
+)
+
+// testWithCassettes runs the scenario against the cassettes recorded for each
+// Kibana version in testdata/cassettes/{version}/{name}.json, and is skipped
+// when none has been recorded, which is the case of every scenario so far. With KIBANA_CASSETTE_RECORD set, the scenario
+// runs against the Kibana of KIBANA_HOST and KIBANA_AUTH instead and its
+// cassette is recorded for the version of that Kibana.
+func testWithCassettes(t *testing.T, name string, scenario func(t *testing.T, client *KibanaClient)) {
+	if os.Getenv("KIBANA_CASSETTE_RECORD") != "" {
+		cassette := myhttp.NewCassetteRecorder()
+		api := myhttp.CreateHTTPClient()
+		api.SetRoundTripper(cassette.RoundTripper)
+		client := &KibanaClient{api: api}
+		client.SetupClient(os.Getenv("KIBANA_HOST"), os.Getenv("KIBANA_AUTH"))
+		version, err := client.DetectVersion()
+		if err != nil {
+			t.Fatal(err)
+		}
+		scenario(t, client)
+		if !t.Failed() {
+			if err := cassette.Save(filepath.Join("testdata", "cassettes", version.String(), name+".json")); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+	paths, err := filepath.Glob(filepath.Join("testdata", "cassettes", "*", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Skipf("No %s cassette recorded, record one against Kibana with KIBANA_CASSETTE_RECORD set", name)
+	}
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(filepath.Dir(path)), func(t *testing.T) {
+			cassette, err := myhttp.LoadCassette(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := myhttp.CreateHTTPClient()
+			api.SetRoundTripper(cassette.RoundTripper)
+			client := &KibanaClient{api: api}
+			client.SetupClient("http://kibana.test", "ZWxhc3RpYzpjaGFuZ2VtZQ==")
+			if _, err := client.DetectVersion(); err != nil {
+				t.Fatal(err)
+			}
+			scenario(t, client)
+			if unplayed := cassette.Unplayed(); !t.Failed() && unplayed > 0 {
+				t.Errorf("%d interactions of %s were not replayed", unplayed, path)
+			}
+		})
+	}
+}
+
+func TestKibanaClientAlertRulesCassettes(t *testing.T) {
+	testWithCassettes(t, "alert_rules", func(t *testing.T, kibanaClient *KibanaClient) {
+		client := SelectAlertsClient(kibanaClient, false)
+		alert := Alert{
+			Name:       "Test alert",
+			Tags:       []string{"ok"},
+			RuleTypeId: ".index-threshold",
+			Consumer:   "alerts",
+			Schedule:   map[string]string{"interval": "1m"},
+			NotifyWhen: "onActiveAlert",
+			Params:     json.RawMessage(`{"aggType":"count","groupBy":"all","index":["logs-*"],"threshold":[1000],"thresholdComparator":">","timeField":"@timestamp","timeWindowSize":5,"timeWindowUnit":"m"}`),
+		}
+		alertId, err := client.CreateAlertRule(alert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read, err := client.ReadAlertRule(alertId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read.Name != alert.Name || read.RuleTypeId != alert.RuleTypeId || !read.Enabled || read.Schedule["interval"] != "1m" {
+			t.Errorf("Unexpected rule %+v", read)
+		}
+		if err := client.DisableRule(alertId); err != nil {
+			t.Fatal(err)
+		}
+		alert.Name = "Renamed alert"
+		alert.RuleTypeId, alert.Consumer = "", ""
+		if err := client.UpdateAlertRule(alertId, alert); err != nil {
+			t.Fatal(err)
+		}
+		read, err = client.ReadAlertRule(alertId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read.Name != "Renamed alert" || read.Enabled {
+			t.Errorf("Unexpected rule %+v", read)
+		}
+		if err := client.DeleteAlertRule(alertId); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.ReadAlertRule(alertId); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Reading a deleted rule should fail with ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestKibanaClientDataViewsCassettes(t *testing.T) {
+	testWithCassettes(t, "data_views", func(t *testing.T, client *KibanaClient) {
+		created, err := client.CreateDataView("", DataView{Title: "logs-*", TimeFieldName: "@timestamp"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.Id == "" || created.Title != "logs-*" {
+			t.Errorf("Unexpected data view %+v", created)
+		}
+		updated, err := client.UpdateDataView("", created.Id, DataView{Title: "logs-*", TimeFieldName: "event.created"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.TimeFieldName != "event.created" {
+			t.Errorf("Unexpected data view %+v", updated)
+		}
+		read, err := client.ReadDataView("", created.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read.Id != created.Id || read.TimeFieldName != "event.created" {
+			t.Errorf("Unexpected data view %+v", read)
+		}
+		if err := client.DeleteDataView("", created.Id); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.ReadDataView("", created.Id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Reading a deleted data view should fail with ErrNotFound, got %v", err)
+		}
+	})
+}
@@ -1,10 +1,12 @@
 package kibana
 
 import (
-	"encoding/base64"
+	"context"
+	"encoding/json"
 	"fmt"
-	"math/rand"
-	"time"
+	"sync"
+
+	"github.com/pkg/errors"
 )
 
 type KibanaMockClient struct {
@@ -16,17 +18,44 @@ type KibanaMockClient struct {
 	ReadAlertResult        Alert
 	EnableAlertShouldFail  bool
 	DisableAlertShouldFail bool
+	// VersionNumber is the Kibana version returned by ReadStatus, unknown
+	// when empty.
+	VersionNumber string
+	// KibanaHost is the Kibana URL the client is set up with.
+	KibanaHost string
+	// Cases are the cases returned by FindCases.
+	Cases                  []Case
 	alerts                 map[string]Alert
+	dataViews              map[string]DataView
+	savedObjects           map[SavedObjectId]SavedObject
+	roles                  map[string]Role
+	settings               map[string]map[string]json.RawMessage
+	agentPolicies          map[string]FleetAgentPolicy
+	packagePolicies        map[string]FleetPackagePolicy
+	packages               map[string]string
+	outputs                map[string]FleetOutput
+	serverHosts            map[string]FleetServerHost
+	proxies                map[string]FleetProxy
+	enrollmentTokens       map[string]FleetEnrollmentToken
+	monitors               map[string]SyntheticsMonitor
+	privateLocations       map[string]SyntheticsPrivateLocation
+	apmAgentConfigurations map[ApmService]ApmAgentConfiguration
+	caseConfigurations     map[string]CaseConfiguration
+	logstashPipelines      map[string]LogstashPipeline
+	slos                   map[string]Slo
+	// mutex guards the fields of the client, which Terraform uses from
+	// concurrent goroutines.
+	mutex  sync.Mutex
+	lastId int
 }
 
 func (c *KibanaMockClient) CreateAlertRule(alert Alert) (alertId string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if c.CreateAlertShouldFail {
 		return "", fmt.Errorf("Creating alert failed")
 	}
-	rand.Seed(time.Now().UnixNano())
-	alertIdBuff := make([]byte, 16)
-	rand.Read(alertIdBuff)
-	alertId = base64.StdEncoding.EncodeToString(alertIdBuff)
+	alertId = c.newId()
 	if c.alerts == nil {
 		c.alerts = make(map[string]Alert)
 	}
@@ -35,6 +64,8 @@ func (c *KibanaMockClient) CreateAlertRule(alert Alert) (alertId string, err err
 }
 
 func (c *KibanaMockClient) DeleteAlertRule(alertId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if c.DeleteAlertShouldFail {
 		return fmt.Errorf("Deleting alert failed")
 	}
@@ -49,6 +80,8 @@ func (c *KibanaMockClient) DeleteAlertRule(alertId string) error {
 }
 
 func (c *KibanaMockClient) UpdateAlertRule(alertId string, alert Alert) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if c.alerts == nil {
 		c.alerts = make(map[string]Alert)
 	}
@@ -65,20 +98,24 @@ func (c *KibanaMockClient) UpdateAlertRule(alertId string, alert Alert) error {
 }
 
 func (c *KibanaMockClient) ReadAlertRule(alertId string) (Alert, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if c.ReadAlertShouldFail {
 		return Alert{}, fmt.Errorf("Reading alert failed")
 	}
 	if c.alerts != nil {
 		alert, ok := c.alerts[alertId]
 		if !ok {
-			return Alert{}, fmt.Errorf("Alert not found")
+			return Alert{}, errors.Wrapf(ErrNotFound, "Rule %s", alertId)
 		}
 		return alert, nil
 	}
-	return Alert{}, fmt.Errorf("Alert not found")
+	return Alert{}, errors.Wrapf(ErrNotFound, "Rule %s", alertId)
 }
 
 func (c *KibanaMockClient) EnableRule(alertId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if c.EnableAlertShouldFail || (alertId == "") {
 		return fmt.Errorf("Enabling alert failed")
 	}
@@ -86,10 +123,65 @@ func (c *KibanaMockClient) EnableRule(alertId string) error {
 }
 
 func (c *KibanaMockClient) DisableRule(alertId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if c.DisableAlertShouldFail || (alertId == "") {
 		return fmt.Errorf("Disabling alert failed")
 	}
 	return nil
 }
 
-func (c *KibanaMockClient) SetupClient(kibana_host, kibana_auth string) {}
+func (c *KibanaMockClient) SetupClient(kibana_host, kibana_auth string) {
+	c.KibanaHost = kibana_host
+}
+
+func (c *KibanaMockClient) SetEndpoints(endpoints []string) error {
+	return nil
+}
+
+func (c *KibanaMockClient) SetProxy(proxyUrl string, fromEnvironment bool) error {
+	return nil
+}
+
+func (c *KibanaMockClient) EnableLogging(ctx context.Context) {}
+
+func (c *KibanaMockClient) SetRateLimit(requestsPerSecond float64, maxConcurrent, maxRetries int) error {
+	return nil
+}
+
+func (c *KibanaMockClient) SetHeaders(headers map[string]string) error {
+	for name := range headers {
+		if IsReservedHeader(name) {
+			return fmt.Errorf("Header %s is set by the provider and can't be overridden", name)
+		}
+	}
+	return nil
+}
+
+// newId returns the next ID of the client, formatted as a UUID. IDs are
+// deterministic and never reused by a client. The caller holds the mutex.
+func (c *KibanaMockClient) newId() string {
+	c.lastId++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", c.lastId)
+}
+
+// mergeUpdate applies a partial update like Fleet does: the fields of update
+// replace the ones of existing, null included, and the omitted fields are
+// kept. The result is decoded into merged.
+func mergeUpdate(existing, update, merged interface{}) error {
+	fields := make(map[string]json.RawMessage)
+	for _, item := range []interface{}{existing, update} {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, merged)
+}
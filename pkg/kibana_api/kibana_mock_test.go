@@ -0,0 +1,100 @@
+package kibana
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestKibanaMockClientConcurrentUse(t *testing.T) {
+	client := &KibanaMockClient{}
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			alertId, err := client.CreateAlertRule(Alert{Name: fmt.Sprintf("rule %d", i)})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err = client.ReadAlertRule(alertId); err != nil {
+				errs <- err
+			}
+			if err = client.DeleteAlertRule(alertId); err != nil {
+				errs <- err
+			}
+		}(i)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			dataView, err := client.CreateDataView("", DataView{Title: fmt.Sprintf("logs-%d-*", i)})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err = client.ReadDataView("", dataView.Id); err != nil {
+				errs <- err
+			}
+			if err = client.DeleteDataView("", dataView.Id); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestKibanaMockClientIds(t *testing.T) {
+	client := &KibanaMockClient{}
+	other := &KibanaMockClient{}
+	ids := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		alertId, err := client.CreateAlertRule(Alert{Name: "rule"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ids[alertId] {
+			t.Fatalf("ID %s was returned twice", alertId)
+		}
+		ids[alertId] = true
+	}
+	otherId, err := other.CreateAlertRule(Alert{Name: "rule"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "00000000-0000-4000-8000-000000000001"; otherId != expected {
+		t.Errorf("Expected the first ID to be %s, got %s", expected, otherId)
+	}
+}
+
+func TestKibanaClientConcurrentHeaders(t *testing.T) {
+	client := &KibanaClient{}
+	client.SetupClient("http://localhost:5601", "auth")
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := client.SetHeaders(map[string]string{fmt.Sprintf("X-Header-%d", i): "value"}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for name := range client.requestHeaders() {
+				if name == "" {
+					t.Error("Empty header name")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if len(client.requestHeaders()) != 23 {
+		t.Errorf("Expected 23 headers, got %d", len(client.requestHeaders()))
+	}
+}
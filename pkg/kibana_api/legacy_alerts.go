@@ -0,0 +1,202 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// LegacyAlertsKibanaClient manages rules with the alerts API of Kibana 7.10 to
+// 7.12, translating them from and to the rule format of KibanaClient. The
+// other objects are managed by KibanaClient.
+type LegacyAlertsKibanaClient struct {
+	*KibanaClient
+}
+
+// SelectAlertsClient returns the client managing rules with the API of the
+// detected Kibana version, or with the legacy alerts API when legacy is set.
+// api is returned unchanged when it is not a KibanaClient.
+func SelectAlertsClient(api KibanaAPI, legacy bool) KibanaAPI {
+	client, ok := api.(*KibanaClient)
+	if !ok {
+		return api
+	}
+	if legacy || !client.version.AtLeast(7, 13) {
+		return &LegacyAlertsKibanaClient{KibanaClient: client}
+	}
+	return client
+}
+
+type legacyAlert struct {
+	Name        string              `json:"name,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	AlertTypeId string              `json:"alertTypeId,omitempty"`
+	Schedule    map[string]string   `json:"schedule,omitempty"`
+	Throttle    string              `json:"throttle,omitempty"`
+	NotifyWhen  string              `json:"notifyWhen,omitempty"`
+	Enabled     bool                `json:"enabled,omitempty"`
+	Consumer    string              `json:"consumer,omitempty"`
+	Params      json.RawMessage     `json:"params,omitempty"`
+	Actions     []legacyAlertAction `json:"actions"`
+}
+
+type legacyAlertAction struct {
+	Id     string          `json:"id"`
+	Group  string          `json:"group"`
+	Params json.RawMessage `json:"params"`
+}
+
+func (c *LegacyAlertsKibanaClient) toLegacyAlert(alert Alert) (legacyAlert, error) {
+	legacy := legacyAlert{
+		Name:        alert.Name,
+		Tags:        alert.Tags,
+		AlertTypeId: alert.RuleTypeId,
+		Schedule:    alert.Schedule,
+		Throttle:    alert.Throttle,
+		NotifyWhen:  alert.NotifyWhen,
+		Enabled:     alert.Enabled,
+		Consumer:    alert.Consumer,
+		Params:      alert.Params,
+		Actions:     []legacyAlertAction{},
+	}
+	// notifyWhen was added in 7.11 and 7.10 rejects it.
+	if c.version.IsKnown() && !c.version.AtLeast(7, 11) {
+		legacy.NotifyWhen = ""
+	}
+	for _, action := range alert.Actions {
+		if action.Frequency != nil {
+			return legacy, fmt.Errorf("Action frequency is not supported by the legacy alerts API")
+		}
+		legacy.Actions = append(legacy.Actions, legacyAlertAction{
+			Id:     action.Id,
+			Group:  action.Group,
+			Params: action.Params,
+		})
+	}
+	return legacy, nil
+}
+
+func (legacy legacyAlert) alert() Alert {
+	alert := Alert{
+		Name:       legacy.Name,
+		Tags:       legacy.Tags,
+		RuleTypeId: legacy.AlertTypeId,
+		Schedule:   legacy.Schedule,
+		Throttle:   legacy.Throttle,
+		NotifyWhen: legacy.NotifyWhen,
+		Enabled:    legacy.Enabled,
+		Consumer:   legacy.Consumer,
+		Params:     legacy.Params,
+	}
+	for _, action := range legacy.Actions {
+		alert.Actions = append(alert.Actions, Action{
+			Id:     action.Id,
+			Group:  action.Group,
+			Params: action.Params,
+		})
+	}
+	return alert
+}
+
+func (c *LegacyAlertsKibanaClient) CreateAlertRule(alert Alert) (alertId string, err error) {
+	var result struct {
+		Id string `json:"id"`
+	}
+	url := fmt.Sprintf("%s/api/alerts/alert", c.host)
+	legacy, err := c.toLegacyAlert(alert)
+	if err != nil {
+		return "", err
+	}
+	jsonAlert, err := json.Marshal(legacy)
+	if err != nil {
+		return "", err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonAlert)
+	if err != nil {
+		return "", errors.Wrapf(err, "Creating alert failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return "", fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonAlert))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.Id, err
+}
+
+func (c *LegacyAlertsKibanaClient) DeleteAlertRule(alertId string) error {
+	url := fmt.Sprintf("%s/api/alerts/alert/%s", c.host, alertId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting alert failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
+
+func (c *LegacyAlertsKibanaClient) UpdateAlertRule(alertId string, alert Alert) error {
+	url := fmt.Sprintf("%s/api/alerts/alert/%s", c.host, alertId)
+	legacy, err := c.toLegacyAlert(alert)
+	if err != nil {
+		return err
+	}
+	// The alert type, the consumer and the enabled state can't be updated
+	// and the legacy API rejects them.
+	legacy.AlertTypeId = ""
+	legacy.Consumer = ""
+	legacy.Enabled = false
+	jsonAlert, err := json.Marshal(legacy)
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonAlert)
+	if err != nil {
+		return errors.Wrapf(err, "Updating alert failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonAlert))
+	}
+	return nil
+}
+
+func (c *LegacyAlertsKibanaClient) ReadAlertRule(alertId string) (Alert, error) {
+	var legacy legacyAlert
+	url := fmt.Sprintf("%s/api/alerts/alert/%s", c.host, alertId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return Alert{}, errors.Wrapf(err, "Reading alert failed")
+	}
+	if statusCode == 404 {
+		return Alert{}, errors.Wrapf(ErrNotFound, "Alert %s", alertId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return Alert{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &legacy)
+	return legacy.alert(), err
+}
+
+func (c *LegacyAlertsKibanaClient) EnableRule(alertId string) error {
+	url := fmt.Sprintf("%s/api/alerts/alert/%s/_enable", c.host, alertId)
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), []byte{})
+	if err != nil {
+		return errors.Wrapf(err, "Enabling alert failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Enable alert - Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
+
+func (c *LegacyAlertsKibanaClient) DisableRule(alertId string) error {
+	url := fmt.Sprintf("%s/api/alerts/alert/%s/_disable", c.host, alertId)
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), []byte{})
+	if err != nil {
+		return errors.Wrapf(err, "Disabling alert failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Disable alert - Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package kibana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	myhttp "github.com/qonto/terraform-provider-kibana/pkg/httpClient"
+)
+
+func newLegacyAlertsClient(version string, responses ...myhttp.FakeResponse) (*LegacyAlertsKibanaClient, *myhttp.HttpClientMock) {
+	mock := &myhttp.HttpClientMock{Resp: responses}
+	parsed, _ := ParseKibanaVersion(version)
+	return &LegacyAlertsKibanaClient{KibanaClient: &KibanaClient{api: mock, host: "http://kibana:5601", version: parsed}}, mock
+}
+
+func testAlert() Alert {
+	return Alert{
+		Name:       "Test alert",
+		Tags:       []string{"ok"},
+		RuleTypeId: ".index-threshold",
+		Schedule:   map[string]string{"interval": "1m"},
+		NotifyWhen: "onActiveAlert",
+		Enabled:    true,
+		Consumer:   "alerts",
+		Params:     json.RawMessage(`{"index":["logs-*"]}`),
+		Actions: []Action{
+			{Id: "c1", Group: "threshold met", Params: json.RawMessage(`{"message":"alert"}`)},
+		},
+	}
+}
+
+func TestSelectAlertsClient(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		legacy  bool
+		want    bool
+	}{
+		{"7.10.2", false, true},
+		{"7.12.1", false, true},
+		{"7.13.0", false, false},
+		{"8.6.0", false, false},
+		{"8.6.0", true, true},
+		{"", false, false},
+	} {
+		client := &KibanaClient{}
+		client.version, _ = ParseKibanaVersion(tc.version)
+		_, isLegacy := SelectAlertsClient(client, tc.legacy).(*LegacyAlertsKibanaClient)
+		if isLegacy != tc.want {
+			t.Errorf("Kibana %q with legacy %v: legacy client %v, expected %v", tc.version, tc.legacy, isLegacy, tc.want)
+		}
+	}
+	mock := &KibanaMockClient{}
+	if SelectAlertsClient(mock, true) != KibanaAPI(mock) {
+		t.Error("Clients other than KibanaClient should be returned unchanged")
+	}
+}
+
+func TestLegacyAlertsClientRequests(t *testing.T) {
+	withoutActions := testAlert()
+	withoutActions.Actions = nil
+	for _, tc := range []struct {
+		name    string
+		version string
+		call    func(client *LegacyAlertsKibanaClient) error
+		method  string
+		url     string
+		body    string
+	}{
+		{
+			name:    "create drops notifyWhen on 7.10",
+			version: "7.10.2",
+			call: func(client *LegacyAlertsKibanaClient) error {
+				_, err := client.CreateAlertRule(testAlert())
+				return err
+			},
+			method: "POST",
+			url:    "http://kibana:5601/api/alerts/alert",
+			body:   `{"name":"Test alert","tags":["ok"],"alertTypeId":".index-threshold","schedule":{"interval":"1m"},"enabled":true,"consumer":"alerts","params":{"index":["logs-*"]},"actions":[{"id":"c1","group":"threshold met","params":{"message":"alert"}}]}`,
+		},
+		{
+			name:    "create keeps notifyWhen since 7.11",
+			version: "7.11.2",
+			call: func(client *LegacyAlertsKibanaClient) error {
+				_, err := client.CreateAlertRule(testAlert())
+				return err
+			},
+			method: "POST",
+			url:    "http://kibana:5601/api/alerts/alert",
+			body:   `{"name":"Test alert","tags":["ok"],"alertTypeId":".index-threshold","schedule":{"interval":"1m"},"notifyWhen":"onActiveAlert","enabled":true,"consumer":"alerts","params":{"index":["logs-*"]},"actions":[{"id":"c1","group":"threshold met","params":{"message":"alert"}}]}`,
+		},
+		{
+			name:    "create sends empty actions",
+			version: "7.12.1",
+			call: func(client *LegacyAlertsKibanaClient) error {
+				_, err := client.CreateAlertRule(withoutActions)
+				return err
+			},
+			method: "POST",
+			url:    "http://kibana:5601/api/alerts/alert",
+			body:   `{"name":"Test alert","tags":["ok"],"alertTypeId":".index-threshold","schedule":{"interval":"1m"},"notifyWhen":"onActiveAlert","enabled":true,"consumer":"alerts","params":{"index":["logs-*"]},"actions":[]}`,
+		},
+		{
+			name:    "update strips the alert type, the consumer and enabled",
+			version: "7.12.1",
+			call: func(client *LegacyAlertsKibanaClient) error {
+				return client.UpdateAlertRule("a1", testAlert())
+			},
+			method: "PUT",
+			url:    "http://kibana:5601/api/alerts/alert/a1",
+			body:   `{"name":"Test alert","tags":["ok"],"schedule":{"interval":"1m"},"notifyWhen":"onActiveAlert","params":{"index":["logs-*"]},"actions":[{"id":"c1","group":"threshold met","params":{"message":"alert"}}]}`,
+		},
+		{
+			name:    "enable",
+			version: "7.12.1",
+			call: func(client *LegacyAlertsKibanaClient) error {
+				return client.EnableRule("a1")
+			},
+			method: "POST",
+			url:    "http://kibana:5601/api/alerts/alert/a1/_enable",
+		},
+		{
+			name:    "disable",
+			version: "7.12.1",
+			call: func(client *LegacyAlertsKibanaClient) error {
+				return client.DisableRule("a1")
+			},
+			method: "POST",
+			url:    "http://kibana:5601/api/alerts/alert/a1/_disable",
+		},
+		{
+			name:    "delete",
+			version: "7.12.1",
+			call: func(client *LegacyAlertsKibanaClient) error {
+				return client.DeleteAlertRule("a1")
+			},
+			method: "DELETE",
+			url:    "http://kibana:5601/api/alerts/alert/a1",
+		},
+	} {
+		client, mock := newLegacyAlertsClient(tc.version, myhttp.FakeResponse{Status: 200, Payload: []byte(`{"id":"a1"}`)})
+		if err := tc.call(client); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if len(mock.Requests) != 1 {
+			t.Errorf("%s: expected 1 request, got %v", tc.name, mock.Requests)
+			continue
+		}
+		request := mock.Requests[0]
+		if request.Method != tc.method || request.Url != tc.url || string(request.Body) != tc.body {
+			t.Errorf("%s: got %s %s %s\nexpected %s %s %s", tc.name, request.Method, request.Url, request.Body, tc.method, tc.url, tc.body)
+		}
+	}
+}
+
+func TestLegacyAlertsClientRejectsActionFrequency(t *testing.T) {
+	client, mock := newLegacyAlertsClient("7.12.1")
+	alert := testAlert()
+	alert.Actions[0].Frequency = &ActionFrequency{NotifyWhen: "onActiveAlert"}
+	if _, err := client.CreateAlertRule(alert); err == nil {
+		t.Error("Action frequency should be rejected")
+	}
+	if len(mock.Requests) != 0 {
+		t.Errorf("No request should be sent, got %v", mock.Requests)
+	}
+}
+
+func TestLegacyAlertsClientRead(t *testing.T) {
+	client, mock := newLegacyAlertsClient("7.12.1", myhttp.FakeResponse{Status: 200, Payload: []byte(`{
+		"id": "a1",
+		"name": "Test alert",
+		"tags": ["ok"],
+		"alertTypeId": ".index-threshold",
+		"consumer": "alerts",
+		"schedule": {"interval": "1m"},
+		"throttle": null,
+		"notifyWhen": "onActiveAlert",
+		"enabled": true,
+		"params": {"index":["logs-*"]},
+		"actions": [{"id": "c1", "group": "threshold met", "params": {"message":"alert"}, "actionTypeId": ".server-log"}],
+		"muteAll": false,
+		"mutedInstanceIds": []
+	}`)})
+	alert, err := client.ReadAlertRule("a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(alert, testAlert()) {
+		t.Errorf("got %+v\nexpected %+v", alert, testAlert())
+	}
+	if mock.Requests[0].Method != "GET" || mock.Requests[0].Url != "http://kibana:5601/api/alerts/alert/a1" {
+		t.Errorf("Unexpected request %v", mock.Requests[0])
+	}
+
+	client, _ = newLegacyAlertsClient("7.12.1", myhttp.FakeResponse{Status: 404, Payload: []byte(`{"statusCode":404,"error":"Not Found","message":"Saved object [alert/a1] not found"}`)})
+	if _, err := client.ReadAlertRule("a1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Reading a missing alert should fail with ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// LogstashPipeline is a pipeline of the Logstash centralized pipeline
+// management.
+type LogstashPipeline struct {
+	Id          string                    `json:"id,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Username    string                    `json:"username,omitempty"`
+	Pipeline    string                    `json:"pipeline"`
+	Settings    *LogstashPipelineSettings `json:"settings,omitempty"`
+}
+
+type LogstashPipelineSettings struct {
+	Workers               int    `json:"pipeline.workers,omitempty"`
+	BatchSize             int    `json:"pipeline.batch.size,omitempty"`
+	BatchDelay            int    `json:"pipeline.batch.delay,omitempty"`
+	QueueType             string `json:"queue.type,omitempty"`
+	QueueMaxBytes         string `json:"queue.max_bytes,omitempty"`
+	QueueCheckpointWrites int    `json:"queue.checkpoint.writes,omitempty"`
+}
+
+// PutLogstashPipeline creates or replaces a pipeline. Kibana records the
+// user calling the API as the username of the pipeline.
+func (c *KibanaClient) PutLogstashPipeline(pipeline LogstashPipeline) error {
+	url := fmt.Sprintf("%s/api/logstash/pipeline/%s", c.host, pipeline.Id)
+	pipelineId := pipeline.Id
+	pipeline.Id = ""
+	pipeline.Username = ""
+	jsonPipeline, err := json.Marshal(pipeline)
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonPipeline)
+	if err != nil {
+		return errors.Wrapf(err, "Saving Logstash pipeline %s failed", pipelineId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonPipeline))
+	}
+	return nil
+}
+
+func (c *KibanaClient) ReadLogstashPipeline(pipelineId string) (LogstashPipeline, error) {
+	var result LogstashPipeline
+	url := fmt.Sprintf("%s/api/logstash/pipeline/%s", c.host, pipelineId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return LogstashPipeline{}, errors.Wrapf(err, "Reading Logstash pipeline failed")
+	}
+	if statusCode == 404 {
+		return LogstashPipeline{}, errors.Wrapf(ErrNotFound, "Logstash pipeline %s", pipelineId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return LogstashPipeline{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) DeleteLogstashPipeline(pipelineId string) error {
+	url := fmt.Sprintf("%s/api/logstash/pipeline/%s", c.host, pipelineId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting Logstash pipeline failed")
+	}
+	if statusCode == 404 {
+		return errors.Wrapf(ErrNotFound, "Logstash pipeline %s", pipelineId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package kibana
+
+import (
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) PutLogstashPipeline(pipeline LogstashPipeline) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.logstashPipelines == nil {
+		c.logstashPipelines = make(map[string]LogstashPipeline)
+	}
+	pipeline.Username = "elastic"
+	c.logstashPipelines[pipeline.Id] = pipeline
+	return nil
+}
+
+func (c *KibanaMockClient) ReadLogstashPipeline(pipelineId string) (LogstashPipeline, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	pipeline, ok := c.logstashPipelines[pipelineId]
+	if !ok {
+		return LogstashPipeline{}, errors.Wrapf(ErrNotFound, "Logstash pipeline %s", pipelineId)
+	}
+	return pipeline, nil
+}
+
+func (c *KibanaMockClient) DeleteLogstashPipeline(pipelineId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.logstashPipelines[pipelineId]; !ok {
+		return errors.Wrapf(ErrNotFound, "Logstash pipeline %s", pipelineId)
+	}
+	delete(c.logstashPipelines, pipelineId)
+	return nil
+}
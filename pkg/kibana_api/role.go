@@ -0,0 +1,88 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type Role struct {
+	Name          string            `json:"name,omitempty"`
+	Metadata      json.RawMessage   `json:"metadata,omitempty"`
+	Elasticsearch RoleElasticsearch `json:"elasticsearch"`
+	Kibana        []RoleKibana      `json:"kibana"`
+}
+
+type RoleElasticsearch struct {
+	Cluster []string    `json:"cluster"`
+	Indices []RoleIndex `json:"indices"`
+	RunAs   []string    `json:"run_as"`
+}
+
+type RoleIndex struct {
+	Names                  []string           `json:"names"`
+	Privileges             []string           `json:"privileges"`
+	FieldSecurity          *RoleFieldSecurity `json:"field_security,omitempty"`
+	Query                  string             `json:"query,omitempty"`
+	AllowRestrictedIndices bool               `json:"allow_restricted_indices,omitempty"`
+}
+
+type RoleFieldSecurity struct {
+	Grant  []string `json:"grant,omitempty"`
+	Except []string `json:"except,omitempty"`
+}
+
+type RoleKibana struct {
+	Base    []string            `json:"base"`
+	Feature map[string][]string `json:"feature"`
+	Spaces  []string            `json:"spaces"`
+}
+
+// PutRole creates the role or replaces it when it already exists.
+func (c *KibanaClient) PutRole(role Role) error {
+	url := fmt.Sprintf("%s/api/security/role/%s", c.host, role.Name)
+	// The name is part of the URL and is rejected in the body.
+	role.Name = ""
+	jsonRole, err := json.Marshal(role)
+	if err != nil {
+		return err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonRole)
+	if err != nil {
+		return errors.Wrapf(err, "Putting role failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonRole))
+	}
+	return nil
+}
+
+func (c *KibanaClient) ReadRole(name string) (Role, error) {
+	var role Role
+	url := fmt.Sprintf("%s/api/security/role/%s", c.host, name)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return role, errors.Wrapf(err, "Reading role failed")
+	}
+	if statusCode == 404 {
+		return role, errors.Wrapf(ErrNotFound, "Role %s", name)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return role, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &role)
+	return role, err
+}
+
+func (c *KibanaClient) DeleteRole(name string) error {
+	url := fmt.Sprintf("%s/api/security/role/%s", c.host, name)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting role failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
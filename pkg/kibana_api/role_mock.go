@@ -0,0 +1,37 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) PutRole(role Role) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.roles == nil {
+		c.roles = make(map[string]Role)
+	}
+	c.roles[role.Name] = role
+	return nil
+}
+
+func (c *KibanaMockClient) ReadRole(name string) (Role, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	role, ok := c.roles[name]
+	if !ok {
+		return Role{}, errors.Wrapf(ErrNotFound, "Role %s", name)
+	}
+	return role, nil
+}
+
+func (c *KibanaMockClient) DeleteRole(name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.roles[name]; !ok {
+		return fmt.Errorf("Deleting role failed - unknown role")
+	}
+	delete(c.roles, name)
+	return nil
+}
@@ -0,0 +1,250 @@
+package kibana
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	myhttp "github.com/qonto/terraform-provider-kibana/pkg/httpClient"
+)
+
+type SavedObject struct {
+	Type                 string                 `json:"type"`
+	Id                   string                 `json:"id,omitempty"`
+	Attributes           json.RawMessage        `json:"attributes,omitempty"`
+	References           []SavedObjectReference `json:"references,omitempty"`
+	Namespaces           []string               `json:"namespaces,omitempty"`
+	Version              string                 `json:"version,omitempty"`
+	UpdatedAt            string                 `json:"updated_at,omitempty"`
+	MigrationVersion     json.RawMessage        `json:"migrationVersion,omitempty"`
+	CoreMigrationVersion string                 `json:"coreMigrationVersion,omitempty"`
+}
+
+type SavedObjectReference struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type SavedObjectId struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type SavedObjectImportResult struct {
+	Type          string `json:"type"`
+	Id            string `json:"id"`
+	DestinationId string `json:"destinationId,omitempty"`
+}
+
+type savedObjectsImportResponse struct {
+	Success        bool                      `json:"success"`
+	SuccessCount   int                       `json:"successCount"`
+	SuccessResults []SavedObjectImportResult `json:"successResults"`
+	Errors         []json.RawMessage         `json:"errors"`
+}
+
+type savedObjectRequest struct {
+	Attributes        json.RawMessage        `json:"attributes"`
+	References        []SavedObjectReference `json:"references"`
+	InitialNamespaces []string               `json:"initialNamespaces,omitempty"`
+}
+
+type savedObjectsExportRequest struct {
+	Objects               []SavedObjectId `json:"objects"`
+	IncludeReferencesDeep bool            `json:"includeReferencesDeep"`
+	ExcludeExportDetails  bool            `json:"excludeExportDetails"`
+}
+
+type savedObjectsExportError struct {
+	Attributes struct {
+		Objects []SavedObjectId `json:"objects"`
+	} `json:"attributes"`
+}
+
+// ParseSavedObjectsNdjson reads saved objects from an NDJSON export. The export
+// details summary line appended by Kibana is skipped.
+func ParseSavedObjectsNdjson(reader io.Reader) ([]SavedObject, error) {
+	objects := []SavedObject{}
+	err := myhttp.DecodeNdjson(reader, func(line json.RawMessage) error {
+		var object SavedObject
+		if err := json.Unmarshal(line, &object); err != nil {
+			return errors.Wrapf(err, "Invalid saved object")
+		}
+		if object.Type == "" {
+			return nil
+		}
+		objects = append(objects, object)
+		return nil
+	})
+	return objects, err
+}
+
+// ImportSavedObjects imports an NDJSON export and returns the objects created
+// or overwritten by Kibana.
+func (c *KibanaClient) ImportSavedObjects(spaceId string, ndjson []byte, overwrite, createNewCopies bool) ([]SavedObjectImportResult, error) {
+	var result savedObjectsImportResponse
+	query := url.Values{}
+	if createNewCopies {
+		query.Set("createNewCopies", "true")
+	} else {
+		query.Set("overwrite", strconv.FormatBool(overwrite))
+	}
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/saved_objects/_import?%s", query.Encode()))
+	r, statusCode, err := c.api.PostMultipart(url, c.requestHeaders(), "file", "export.ndjson", bytes.NewReader(ndjson))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Importing saved objects failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return nil, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	if err = json.Unmarshal(r, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		importErrors := make([]string, 0, len(result.Errors))
+		for _, importError := range result.Errors {
+			importErrors = append(importErrors, string(importError))
+		}
+		return result.SuccessResults, fmt.Errorf("Importing saved objects failed for %d objects: %s", len(result.Errors), importErrors)
+	}
+	return result.SuccessResults, nil
+}
+
+// ExportSavedObjects exports the given objects without their references.
+// Objects that don't exist anymore are left out of the result.
+func (c *KibanaClient) ExportSavedObjects(spaceId string, objects []SavedObjectId) ([]SavedObject, error) {
+	url := c.spaceUrl(spaceId, "/api/saved_objects/_export")
+	for len(objects) > 0 {
+		jsonRequest, err := json.Marshal(savedObjectsExportRequest{Objects: objects, ExcludeExportDetails: true})
+		if err != nil {
+			return nil, err
+		}
+		body, statusCode, err := c.api.PostReturnReader(url, c.requestHeaders(), jsonRequest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Exporting saved objects failed")
+		}
+		if statusCode == 200 {
+			defer body.Close()
+			return ParseSavedObjectsNdjson(body)
+		}
+		r, err := ioutil.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// Kibana refuses the whole export when some objects are missing and
+		// lists them in the error, export the remaining ones instead.
+		var exportError savedObjectsExportError
+		if statusCode != 400 || json.Unmarshal(r, &exportError) != nil || len(exportError.Attributes.Objects) == 0 {
+			return nil, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+		}
+		remaining := withoutSavedObjects(objects, exportError.Attributes.Objects)
+		if len(remaining) == len(objects) {
+			return nil, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+		}
+		objects = remaining
+	}
+	return []SavedObject{}, nil
+}
+
+func (c *KibanaClient) CreateSavedObject(spaceId string, object SavedObject) (SavedObject, error) {
+	var result SavedObject
+	path := fmt.Sprintf("/api/saved_objects/%s", object.Type)
+	if object.Id != "" {
+		path = fmt.Sprintf("%s/%s", path, object.Id)
+	}
+	url := c.spaceUrl(spaceId, path)
+	jsonObject, err := json.Marshal(savedObjectRequest{
+		Attributes:        object.Attributes,
+		References:        object.References,
+		InitialNamespaces: object.Namespaces,
+	})
+	if err != nil {
+		return result, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonObject)
+	if err != nil {
+		return result, errors.Wrapf(err, "Creating saved object failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return result, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonObject))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) ReadSavedObject(spaceId, objectType, objectId string) (SavedObject, error) {
+	var result SavedObject
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/saved_objects/%s/%s", objectType, objectId))
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return result, errors.Wrapf(err, "Reading saved object failed")
+	}
+	if statusCode == 404 {
+		return result, errors.Wrapf(ErrNotFound, "Saved object %s/%s", objectType, objectId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return result, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) UpdateSavedObject(spaceId string, object SavedObject) (SavedObject, error) {
+	var result SavedObject
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/saved_objects/%s/%s", object.Type, object.Id))
+	jsonObject, err := json.Marshal(savedObjectRequest{
+		Attributes: object.Attributes,
+		References: object.References,
+	})
+	if err != nil {
+		return result, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonObject)
+	if err != nil {
+		return result, errors.Wrapf(err, "Updating saved object failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return result, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonObject))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) DeleteSavedObject(spaceId, objectType, objectId string) error {
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/saved_objects/%s/%s", objectType, objectId))
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting saved object failed")
+	}
+	if statusCode == 404 {
+		return errors.Wrapf(ErrNotFound, "Saved object %s/%s", objectType, objectId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
+
+func withoutSavedObjects(objects []SavedObjectId, excluded []SavedObjectId) []SavedObjectId {
+	remaining := []SavedObjectId{}
+	for _, object := range objects {
+		found := false
+		for _, exclude := range excluded {
+			if object == exclude {
+				found = true
+				break
+			}
+		}
+		if !found {
+			remaining = append(remaining, object)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,121 @@
+package kibana
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) ImportSavedObjects(spaceId string, ndjson []byte, overwrite, createNewCopies bool) ([]SavedObjectImportResult, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	objects, err := ParseSavedObjectsNdjson(bytes.NewReader(ndjson))
+	if err != nil {
+		return nil, err
+	}
+	if c.savedObjects == nil {
+		c.savedObjects = make(map[SavedObjectId]SavedObject)
+	}
+	// New copies get regenerated IDs, which the references between the
+	// imported objects follow.
+	destinationIds := make(map[SavedObjectId]string)
+	if createNewCopies {
+		for _, object := range objects {
+			destinationIds[SavedObjectId{Type: object.Type, Id: object.Id}] = c.newId()
+		}
+	}
+	results := []SavedObjectImportResult{}
+	for _, object := range objects {
+		result := SavedObjectImportResult{Type: object.Type, Id: object.Id}
+		if createNewCopies {
+			result.DestinationId = destinationIds[SavedObjectId{Type: object.Type, Id: object.Id}]
+			object.Id = result.DestinationId
+			references := make([]SavedObjectReference, 0, len(object.References))
+			for _, reference := range object.References {
+				if id, ok := destinationIds[SavedObjectId{Type: reference.Type, Id: reference.Id}]; ok {
+					reference.Id = id
+				}
+				references = append(references, reference)
+			}
+			object.References = references
+		}
+		key := SavedObjectId{Type: object.Type, Id: object.Id}
+		if _, ok := c.savedObjects[key]; ok && !overwrite && !createNewCopies {
+			return results, fmt.Errorf("Importing saved objects failed - conflict on %s/%s", object.Type, object.Id)
+		}
+		c.savedObjects[key] = object
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (c *KibanaMockClient) ExportSavedObjects(spaceId string, objects []SavedObjectId) ([]SavedObject, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	exported := []SavedObject{}
+	for _, object := range objects {
+		if savedObject, ok := c.savedObjects[object]; ok {
+			exported = append(exported, savedObject)
+		}
+	}
+	return exported, nil
+}
+
+func (c *KibanaMockClient) CreateSavedObject(spaceId string, object SavedObject) (SavedObject, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.savedObjects == nil {
+		c.savedObjects = make(map[SavedObjectId]SavedObject)
+	}
+	if object.Id == "" {
+		object.Id = c.newId()
+	}
+	key := SavedObjectId{Type: object.Type, Id: object.Id}
+	if _, ok := c.savedObjects[key]; ok {
+		return SavedObject{}, fmt.Errorf("Creating saved object failed - conflict on %s/%s", object.Type, object.Id)
+	}
+	if len(object.Namespaces) == 0 && spaceId != "" {
+		object.Namespaces = []string{spaceId}
+	} else if len(object.Namespaces) == 0 {
+		object.Namespaces = []string{"default"}
+	}
+	object.Version = "WzEsMV0="
+	c.savedObjects[key] = object
+	return object, nil
+}
+
+func (c *KibanaMockClient) ReadSavedObject(spaceId, objectType, objectId string) (SavedObject, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	object, ok := c.savedObjects[SavedObjectId{Type: objectType, Id: objectId}]
+	if !ok {
+		return SavedObject{}, errors.Wrapf(ErrNotFound, "Saved object %s/%s", objectType, objectId)
+	}
+	return object, nil
+}
+
+func (c *KibanaMockClient) UpdateSavedObject(spaceId string, object SavedObject) (SavedObject, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := SavedObjectId{Type: object.Type, Id: object.Id}
+	existing, ok := c.savedObjects[key]
+	if !ok {
+		return SavedObject{}, fmt.Errorf("Failed updating saved object - unknown id")
+	}
+	existing.Attributes = object.Attributes
+	existing.References = object.References
+	c.savedObjects[key] = existing
+	return existing, nil
+}
+
+func (c *KibanaMockClient) DeleteSavedObject(spaceId, objectType, objectId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := SavedObjectId{Type: objectType, Id: objectId}
+	if _, ok := c.savedObjects[key]; !ok {
+		return errors.Wrapf(ErrNotFound, "Saved object %s/%s", objectType, objectId)
+	}
+	delete(c.savedObjects, key)
+	return nil
+}
@@ -0,0 +1,253 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	SloIndicatorKqlCustom       = "sli.kql.custom"
+	SloIndicatorMetricCustom    = "sli.metric.custom"
+	SloIndicatorHistogramCustom = "sli.histogram.custom"
+	SloIndicatorApmLatency      = "sli.apm.transactionDuration"
+	SloIndicatorApmAvailability = "sli.apm.transactionErrorRate"
+	SloIndicatorTimesliceMetric = "sli.metric.timeslice"
+	sloGroupByAll               = "*"
+)
+
+// Slo is a service level objective of the Observability app.
+type Slo struct {
+	Id              string        `json:"id,omitempty"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description"`
+	Indicator       SloIndicator  `json:"indicator"`
+	TimeWindow      SloTimeWindow `json:"timeWindow"`
+	BudgetingMethod string        `json:"budgetingMethod"`
+	Objective       SloObjective  `json:"objective"`
+	Settings        *SloSettings  `json:"settings,omitempty"`
+	GroupBy         SloGroupBy    `json:"groupBy"`
+	Tags            []string      `json:"tags"`
+}
+
+// SloIndicator is the indicator of an SLO, whose params depend on its type:
+// SloKqlCustomParams, SloMetricCustomParams, SloHistogramCustomParams,
+// SloApmParams or SloTimesliceMetricParams.
+type SloIndicator struct {
+	Type   string          `json:"type"`
+	Params json.RawMessage `json:"params"`
+}
+
+type SloTimeWindow struct {
+	Duration string `json:"duration"`
+	Type     string `json:"type"`
+}
+
+type SloObjective struct {
+	Target          float64 `json:"target"`
+	TimesliceTarget float64 `json:"timesliceTarget,omitempty"`
+	TimesliceWindow string  `json:"timesliceWindow,omitempty"`
+}
+
+type SloSettings struct {
+	SyncDelay              string `json:"syncDelay,omitempty"`
+	Frequency              string `json:"frequency,omitempty"`
+	PreventInitialBackfill bool   `json:"preventInitialBackfill,omitempty"`
+}
+
+// SloGroupBy lists the fields SLO instances are created for. Kibana takes
+// a single field as a string, * meaning no grouping, and lists since 8.14.
+type SloGroupBy []string
+
+func (g SloGroupBy) MarshalJSON() ([]byte, error) {
+	switch len(g) {
+	case 0:
+		return json.Marshal(sloGroupByAll)
+	case 1:
+		return json.Marshal(g[0])
+	}
+	return json.Marshal([]string(g))
+}
+
+func (g *SloGroupBy) UnmarshalJSON(data []byte) error {
+	var fields []string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		var field string
+		if err := json.Unmarshal(data, &field); err != nil {
+			return err
+		}
+		fields = []string{field}
+	}
+	*g = nil
+	for _, field := range fields {
+		if field != sloGroupByAll && field != "" {
+			*g = append(*g, field)
+		}
+	}
+	return nil
+}
+
+// SloKqlQuery is a KQL query, which Kibana may return as an object holding
+// the query and filters.
+type SloKqlQuery string
+
+func (q *SloKqlQuery) UnmarshalJSON(data []byte) error {
+	var query string
+	if err := json.Unmarshal(data, &query); err == nil {
+		*q = SloKqlQuery(query)
+		return nil
+	}
+	var object struct {
+		KqlQuery string `json:"kqlQuery"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*q = SloKqlQuery(object.KqlQuery)
+	return nil
+}
+
+type SloKqlCustomParams struct {
+	Index          string      `json:"index"`
+	DataViewId     string      `json:"dataViewId,omitempty"`
+	Filter         SloKqlQuery `json:"filter,omitempty"`
+	Good           SloKqlQuery `json:"good"`
+	Total          SloKqlQuery `json:"total"`
+	TimestampField string      `json:"timestampField"`
+}
+
+type SloMetricCustomParams struct {
+	Index          string              `json:"index"`
+	DataViewId     string              `json:"dataViewId,omitempty"`
+	Filter         SloKqlQuery         `json:"filter,omitempty"`
+	TimestampField string              `json:"timestampField"`
+	Good           SloMetricCustomSide `json:"good"`
+	Total          SloMetricCustomSide `json:"total"`
+}
+
+type SloMetricCustomSide struct {
+	Metrics  []SloMetric `json:"metrics"`
+	Equation string      `json:"equation"`
+}
+
+type SloMetric struct {
+	Name        string      `json:"name"`
+	Aggregation string      `json:"aggregation"`
+	Field       string      `json:"field,omitempty"`
+	Percentile  float64     `json:"percentile,omitempty"`
+	Filter      SloKqlQuery `json:"filter,omitempty"`
+}
+
+type SloHistogramCustomParams struct {
+	Index          string           `json:"index"`
+	DataViewId     string           `json:"dataViewId,omitempty"`
+	Filter         SloKqlQuery      `json:"filter,omitempty"`
+	TimestampField string           `json:"timestampField"`
+	Good           SloHistogramSide `json:"good"`
+	Total          SloHistogramSide `json:"total"`
+}
+
+type SloHistogramSide struct {
+	Aggregation string      `json:"aggregation"`
+	Field       string      `json:"field"`
+	From        *float64    `json:"from,omitempty"`
+	To          *float64    `json:"to,omitempty"`
+	Filter      SloKqlQuery `json:"filter,omitempty"`
+}
+
+// SloApmParams are the params of the APM latency and availability
+// indicators, only the latency indicator has a threshold.
+type SloApmParams struct {
+	Service         string      `json:"service"`
+	Environment     string      `json:"environment"`
+	TransactionType string      `json:"transactionType"`
+	TransactionName string      `json:"transactionName"`
+	Index           string      `json:"index"`
+	Filter          SloKqlQuery `json:"filter,omitempty"`
+	Threshold       float64     `json:"threshold,omitempty"`
+}
+
+type SloTimesliceMetricParams struct {
+	Index          string                       `json:"index"`
+	DataViewId     string                       `json:"dataViewId,omitempty"`
+	Filter         SloKqlQuery                  `json:"filter,omitempty"`
+	TimestampField string                       `json:"timestampField"`
+	Metric         SloTimesliceMetricDefinition `json:"metric"`
+}
+
+type SloTimesliceMetricDefinition struct {
+	Metrics    []SloMetric `json:"metrics"`
+	Equation   string      `json:"equation"`
+	Comparator string      `json:"comparator"`
+	Threshold  float64     `json:"threshold"`
+}
+
+func (c *KibanaClient) CreateSlo(spaceId string, slo Slo) (Slo, error) {
+	var result Slo
+	url := c.spaceUrl(spaceId, "/api/observability/slos")
+	jsonSlo, err := json.Marshal(slo)
+	if err != nil {
+		return Slo{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonSlo)
+	if err != nil {
+		return Slo{}, errors.Wrapf(err, "Creating SLO failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return Slo{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonSlo))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) ReadSlo(spaceId, sloId string) (Slo, error) {
+	var result Slo
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/observability/slos/%s", sloId))
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return Slo{}, errors.Wrapf(err, "Reading SLO failed")
+	}
+	if statusCode == 404 {
+		return Slo{}, errors.Wrapf(ErrNotFound, "SLO %s", sloId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return Slo{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) UpdateSlo(spaceId, sloId string, slo Slo) (Slo, error) {
+	var result Slo
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/observability/slos/%s", sloId))
+	slo.Id = ""
+	jsonSlo, err := json.Marshal(slo)
+	if err != nil {
+		return Slo{}, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonSlo)
+	if err != nil {
+		return Slo{}, errors.Wrapf(err, "Updating SLO failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return Slo{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonSlo))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) DeleteSlo(spaceId, sloId string) error {
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/observability/slos/%s", sloId))
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting SLO failed")
+	}
+	if statusCode == 404 {
+		return errors.Wrapf(ErrNotFound, "SLO %s", sloId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateSlo(spaceId string, slo Slo) (Slo, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.slos == nil {
+		c.slos = make(map[string]Slo)
+	}
+	if slo.Id == "" {
+		slo.Id = c.newId()
+	}
+	if _, ok := c.slos[slo.Id]; ok {
+		return Slo{}, fmt.Errorf("Creating SLO failed - duplicate id")
+	}
+	if slo.Settings == nil {
+		slo.Settings = &SloSettings{SyncDelay: "1m", Frequency: "1m"}
+	}
+	c.slos[slo.Id] = slo
+	return Slo{Id: slo.Id}, nil
+}
+
+func (c *KibanaMockClient) ReadSlo(spaceId, sloId string) (Slo, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	slo, ok := c.slos[sloId]
+	if !ok {
+		return Slo{}, errors.Wrapf(ErrNotFound, "SLO %s", sloId)
+	}
+	return slo, nil
+}
+
+func (c *KibanaMockClient) UpdateSlo(spaceId, sloId string, slo Slo) (Slo, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing, ok := c.slos[sloId]
+	if !ok {
+		return Slo{}, fmt.Errorf("Failed updating SLO - unknown id")
+	}
+	slo.Id = sloId
+	if slo.Settings == nil {
+		slo.Settings = existing.Settings
+	}
+	c.slos[sloId] = slo
+	return slo, nil
+}
+
+func (c *KibanaMockClient) DeleteSlo(spaceId, sloId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.slos[sloId]; !ok {
+		return errors.Wrapf(ErrNotFound, "SLO %s", sloId)
+	}
+	delete(c.slos, sloId)
+	return nil
+}
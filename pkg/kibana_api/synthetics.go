@@ -0,0 +1,276 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// SyntheticsMonitor is a monitor of the Synthetics app. Only the fields of
+// its type are sent to Kibana, which rejects the other ones.
+type SyntheticsMonitor struct {
+	Id               string                  `json:"id,omitempty"`
+	Type             string                  `json:"type"`
+	Name             string                  `json:"name"`
+	Enabled          bool                    `json:"enabled"`
+	Schedule         *SyntheticsSchedule     `json:"schedule,omitempty"`
+	Locations        []string                `json:"locations,omitempty"`
+	PrivateLocations []string                `json:"private_locations,omitempty"`
+	Alert            *SyntheticsMonitorAlert `json:"alert,omitempty"`
+	Tags             []string                `json:"tags"`
+	Params           json.RawMessage         `json:"params,omitempty"`
+	Namespace        string                  `json:"namespace,omitempty"`
+	Timeout          int                     `json:"timeout,omitempty"`
+	// http monitors
+	Url          string `json:"url,omitempty"`
+	MaxRedirects int    `json:"max_redirects,omitempty"`
+	Mode         string `json:"mode,omitempty"`
+	Ipv4         *bool  `json:"ipv4,omitempty"`
+	Ipv6         *bool  `json:"ipv6,omitempty"`
+	ProxyUrl     string `json:"proxy_url,omitempty"`
+	// tcp and icmp monitors
+	Host string `json:"host,omitempty"`
+	Wait int    `json:"wait,omitempty"`
+	// browser monitors
+	InlineScript string `json:"inline_script,omitempty"`
+	Screenshots  string `json:"screenshots,omitempty"`
+}
+
+// SyntheticsSchedule is the interval between two checks, in minutes (m) or
+// in seconds (s). Kibana reads a plain number as minutes, so only schedules
+// in seconds are sent with their unit.
+type SyntheticsSchedule struct {
+	Number int
+	Unit   string
+}
+
+func (s SyntheticsSchedule) MarshalJSON() ([]byte, error) {
+	if s.Unit == "" || s.Unit == "m" {
+		return json.Marshal(s.Number)
+	}
+	return json.Marshal(map[string]string{"number": strconv.Itoa(s.Number), "unit": s.Unit})
+}
+
+type SyntheticsMonitorAlert struct {
+	Status SyntheticsMonitorAlertToggle `json:"status"`
+	Tls    SyntheticsMonitorAlertToggle `json:"tls"`
+}
+
+type SyntheticsMonitorAlertToggle struct {
+	Enabled bool `json:"enabled"`
+}
+
+// syntheticsMonitorResponse is the monitor returned by Kibana, which uses
+// other formats than the request: the schedule is an object, the private
+// locations are listed with the public ones and numbers are strings.
+type syntheticsMonitorResponse struct {
+	SyntheticsMonitor
+	ConfigId string `json:"config_id"`
+	Schedule struct {
+		Number string `json:"number"`
+		Unit   string `json:"unit"`
+	} `json:"schedule"`
+	Locations []struct {
+		Id               string `json:"id"`
+		IsServiceManaged bool   `json:"isServiceManaged"`
+	} `json:"locations"`
+	Params       json.RawMessage `json:"params"`
+	Timeout      json.RawMessage `json:"timeout"`
+	MaxRedirects json.RawMessage `json:"max_redirects"`
+	Wait         json.RawMessage `json:"wait"`
+	// Kibana versions before 8.15 return the saved object format.
+	Hosts              string `json:"hosts"`
+	SourceInlineScript string `json:"source.inline.script"`
+}
+
+func (r syntheticsMonitorResponse) monitor() SyntheticsMonitor {
+	monitor := r.SyntheticsMonitor
+	if monitor.Id == "" {
+		monitor.Id = r.ConfigId
+	}
+	monitor.Schedule = nil
+	if number, err := strconv.Atoi(r.Schedule.Number); err == nil {
+		monitor.Schedule = &SyntheticsSchedule{Number: number, Unit: "m"}
+		switch r.Schedule.Unit {
+		case "s":
+			if number%60 == 0 {
+				monitor.Schedule.Number = number / 60
+			} else {
+				monitor.Schedule.Unit = "s"
+			}
+		case "h":
+			monitor.Schedule.Number = number * 60
+		}
+	}
+	monitor.Locations = nil
+	monitor.PrivateLocations = nil
+	for _, location := range r.Locations {
+		if location.IsServiceManaged {
+			monitor.Locations = append(monitor.Locations, location.Id)
+		} else {
+			monitor.PrivateLocations = append(monitor.PrivateLocations, location.Id)
+		}
+	}
+	monitor.Params = nil
+	var stringParams string
+	if err := json.Unmarshal(r.Params, &stringParams); err == nil {
+		if stringParams != "" && stringParams != "{}" {
+			monitor.Params = json.RawMessage(stringParams)
+		}
+	} else if len(r.Params) > 0 && string(r.Params) != "null" && string(r.Params) != "{}" {
+		monitor.Params = r.Params
+	}
+	monitor.Timeout = parseSyntheticsNumber(r.Timeout)
+	monitor.MaxRedirects = parseSyntheticsNumber(r.MaxRedirects)
+	monitor.Wait = parseSyntheticsNumber(r.Wait)
+	if monitor.Host == "" {
+		monitor.Host = r.Hosts
+	}
+	if monitor.InlineScript == "" {
+		monitor.InlineScript = r.SourceInlineScript
+	}
+	return monitor
+}
+
+// parseSyntheticsNumber reads numbers which Kibana may return as strings.
+func parseSyntheticsNumber(raw json.RawMessage) int {
+	var number float64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return int(number)
+	}
+	var stringNumber string
+	if err := json.Unmarshal(raw, &stringNumber); err == nil {
+		number, _ = strconv.ParseFloat(stringNumber, 64)
+	}
+	return int(number)
+}
+
+func (c *KibanaClient) CreateSyntheticsMonitor(spaceId string, monitor SyntheticsMonitor) (SyntheticsMonitor, error) {
+	var result syntheticsMonitorResponse
+	url := c.spaceUrl(spaceId, "/api/synthetics/monitors")
+	jsonMonitor, err := json.Marshal(monitor)
+	if err != nil {
+		return SyntheticsMonitor{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonMonitor)
+	if err != nil {
+		return SyntheticsMonitor{}, errors.Wrapf(err, "Creating synthetics monitor failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return SyntheticsMonitor{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.monitor(), err
+}
+
+func (c *KibanaClient) ReadSyntheticsMonitor(spaceId, monitorId string) (SyntheticsMonitor, error) {
+	var result syntheticsMonitorResponse
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/synthetics/monitors/%s", monitorId))
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return SyntheticsMonitor{}, errors.Wrapf(err, "Reading synthetics monitor failed")
+	}
+	if statusCode == 404 {
+		return SyntheticsMonitor{}, errors.Wrapf(ErrNotFound, "Synthetics monitor %s", monitorId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return SyntheticsMonitor{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.monitor(), err
+}
+
+func (c *KibanaClient) UpdateSyntheticsMonitor(spaceId, monitorId string, monitor SyntheticsMonitor) (SyntheticsMonitor, error) {
+	var result syntheticsMonitorResponse
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/synthetics/monitors/%s", monitorId))
+	monitor.Id = ""
+	jsonMonitor, err := json.Marshal(monitor)
+	if err != nil {
+		return SyntheticsMonitor{}, err
+	}
+	r, statusCode, err := c.api.Put(url, c.requestHeaders(), jsonMonitor)
+	if err != nil {
+		return SyntheticsMonitor{}, errors.Wrapf(err, "Updating synthetics monitor failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return SyntheticsMonitor{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result.monitor(), err
+}
+
+func (c *KibanaClient) DeleteSyntheticsMonitor(spaceId, monitorId string) error {
+	url := c.spaceUrl(spaceId, fmt.Sprintf("/api/synthetics/monitors/%s", monitorId))
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting synthetics monitor failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
+
+// SyntheticsPrivateLocation is a location running monitors on the agents of a
+// Fleet agent policy.
+type SyntheticsPrivateLocation struct {
+	Id            string                        `json:"id,omitempty"`
+	Label         string                        `json:"label"`
+	AgentPolicyId string                        `json:"agentPolicyId"`
+	Tags          []string                      `json:"tags,omitempty"`
+	Geo           *SyntheticsPrivateLocationGeo `json:"geo,omitempty"`
+}
+
+type SyntheticsPrivateLocationGeo struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func (c *KibanaClient) CreateSyntheticsPrivateLocation(location SyntheticsPrivateLocation) (SyntheticsPrivateLocation, error) {
+	var result SyntheticsPrivateLocation
+	url := fmt.Sprintf("%s/api/synthetics/private_locations", c.host)
+	jsonLocation, err := json.Marshal(location)
+	if err != nil {
+		return SyntheticsPrivateLocation{}, err
+	}
+	r, statusCode, err := c.api.Post(url, c.requestHeaders(), jsonLocation)
+	if err != nil {
+		return SyntheticsPrivateLocation{}, errors.Wrapf(err, "Creating synthetics private location failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return SyntheticsPrivateLocation{}, fmt.Errorf("Received status %d: %s\nRequest body:\n%s", statusCode, string(r), string(jsonLocation))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) ReadSyntheticsPrivateLocation(locationId string) (SyntheticsPrivateLocation, error) {
+	var result SyntheticsPrivateLocation
+	url := fmt.Sprintf("%s/api/synthetics/private_locations/%s", c.host, locationId)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return SyntheticsPrivateLocation{}, errors.Wrapf(err, "Reading synthetics private location failed")
+	}
+	if statusCode == 404 {
+		return SyntheticsPrivateLocation{}, errors.Wrapf(ErrNotFound, "Synthetics private location %s", locationId)
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return SyntheticsPrivateLocation{}, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &result)
+	return result, err
+}
+
+func (c *KibanaClient) DeleteSyntheticsPrivateLocation(locationId string) error {
+	url := fmt.Sprintf("%s/api/synthetics/private_locations/%s", c.host, locationId)
+	r, statusCode, err := c.api.Delete(url, c.requestHeaders())
+	if err != nil {
+		return errors.Wrapf(err, "Deleting synthetics private location failed")
+	}
+	if statusCode != 200 && statusCode != 204 {
+		return fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	return nil
+}
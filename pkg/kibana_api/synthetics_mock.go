@@ -0,0 +1,97 @@
+package kibana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (c *KibanaMockClient) CreateSyntheticsMonitor(spaceId string, monitor SyntheticsMonitor) (SyntheticsMonitor, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.monitors == nil {
+		c.monitors = make(map[string]SyntheticsMonitor)
+	}
+	monitor.Id = c.newId()
+	if monitor.Alert == nil {
+		monitor.Alert = &SyntheticsMonitorAlert{Status: SyntheticsMonitorAlertToggle{Enabled: true}, Tls: SyntheticsMonitorAlertToggle{Enabled: true}}
+	}
+	c.monitors[monitor.Id] = monitor
+	return monitor, nil
+}
+
+func (c *KibanaMockClient) ReadSyntheticsMonitor(spaceId, monitorId string) (SyntheticsMonitor, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	monitor, ok := c.monitors[monitorId]
+	if !ok {
+		return SyntheticsMonitor{}, errors.Wrapf(ErrNotFound, "Synthetics monitor %s", monitorId)
+	}
+	return monitor, nil
+}
+
+func (c *KibanaMockClient) UpdateSyntheticsMonitor(spaceId, monitorId string, monitor SyntheticsMonitor) (SyntheticsMonitor, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	existing, ok := c.monitors[monitorId]
+	if !ok {
+		return SyntheticsMonitor{}, fmt.Errorf("Failed updating synthetics monitor - unknown id")
+	}
+	if existing.Type != monitor.Type {
+		return SyntheticsMonitor{}, fmt.Errorf("Failed updating synthetics monitor - type can't be changed")
+	}
+	monitor.Id = monitorId
+	if monitor.Alert == nil {
+		monitor.Alert = existing.Alert
+	}
+	c.monitors[monitorId] = monitor
+	return monitor, nil
+}
+
+func (c *KibanaMockClient) DeleteSyntheticsMonitor(spaceId, monitorId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.monitors[monitorId]; !ok {
+		return fmt.Errorf("Deleting synthetics monitor failed - unknown id")
+	}
+	delete(c.monitors, monitorId)
+	return nil
+}
+
+func (c *KibanaMockClient) CreateSyntheticsPrivateLocation(location SyntheticsPrivateLocation) (SyntheticsPrivateLocation, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.privateLocations == nil {
+		c.privateLocations = make(map[string]SyntheticsPrivateLocation)
+	}
+	location.Id = c.newId()
+	c.privateLocations[location.Id] = location
+	return location, nil
+}
+
+func (c *KibanaMockClient) ReadSyntheticsPrivateLocation(locationId string) (SyntheticsPrivateLocation, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	location, ok := c.privateLocations[locationId]
+	if !ok {
+		return SyntheticsPrivateLocation{}, errors.Wrapf(ErrNotFound, "Synthetics private location %s", locationId)
+	}
+	return location, nil
+}
+
+func (c *KibanaMockClient) DeleteSyntheticsPrivateLocation(locationId string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.privateLocations[locationId]; !ok {
+		return fmt.Errorf("Deleting synthetics private location failed - unknown id")
+	}
+	for _, monitor := range c.monitors {
+		for _, id := range monitor.PrivateLocations {
+			if id == locationId {
+				return fmt.Errorf("Deleting synthetics private location failed - used by monitor %s", monitor.Id)
+			}
+		}
+	}
+	delete(c.privateLocations, locationId)
+	return nil
+}
@@ -0,0 +1,55 @@
+package kibana
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSyntheticsMonitorResponseSchedule(t *testing.T) {
+	for _, tc := range []struct {
+		schedule string
+		number   int
+		unit     string
+	}{
+		{`{"number":"3","unit":"m"}`, 3, "m"},
+		{`{"number":"2","unit":"h"}`, 120, "m"},
+		{`{"number":"120","unit":"s"}`, 2, "m"},
+		{`{"number":"30","unit":"s"}`, 30, "s"},
+		{`{"number":"10","unit":"s"}`, 10, "s"},
+	} {
+		var response syntheticsMonitorResponse
+		if err := json.Unmarshal([]byte(`{"schedule":`+tc.schedule+`}`), &response); err != nil {
+			t.Fatal(err)
+		}
+		schedule := response.monitor().Schedule
+		if schedule == nil || schedule.Number != tc.number || schedule.Unit != tc.unit {
+			t.Errorf("Schedule %s read as %+v, expected %d%s", tc.schedule, schedule, tc.number, tc.unit)
+		}
+	}
+	var response syntheticsMonitorResponse
+	if err := json.Unmarshal([]byte(`{}`), &response); err != nil {
+		t.Fatal(err)
+	}
+	if schedule := response.monitor().Schedule; schedule != nil {
+		t.Errorf("Missing schedule read as %+v", schedule)
+	}
+}
+
+func TestSyntheticsScheduleMarshal(t *testing.T) {
+	for _, tc := range []struct {
+		schedule SyntheticsSchedule
+		want     string
+	}{
+		{SyntheticsSchedule{Number: 5, Unit: "m"}, `5`},
+		{SyntheticsSchedule{Number: 5}, `5`},
+		{SyntheticsSchedule{Number: 30, Unit: "s"}, `{"number":"30","unit":"s"}`},
+	} {
+		got, err := json.Marshal(tc.schedule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("Schedule %+v marshalled as %s, expected %s", tc.schedule, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package kibana
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// KibanaVersion is the version of the Kibana server. The zero value is an
+// unknown version.
+type KibanaVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseKibanaVersion parses a version number such as 8.6.2 or
+// 8.7.0-SNAPSHOT.
+func ParseKibanaVersion(number string) (KibanaVersion, error) {
+	var version KibanaVersion
+	numbers := strings.SplitN(strings.SplitN(number, "-", 2)[0], ".", 3)
+	if len(numbers) < 2 {
+		return version, fmt.Errorf("Invalid Kibana version %q", number)
+	}
+	parts := []*int{&version.Major, &version.Minor, &version.Patch}
+	for i, n := range numbers {
+		part, err := strconv.Atoi(n)
+		if err != nil {
+			return KibanaVersion{}, fmt.Errorf("Invalid Kibana version %q", number)
+		}
+		*parts[i] = part
+	}
+	return version, nil
+}
+
+// IsKnown reports whether the version was detected.
+func (v KibanaVersion) IsKnown() bool {
+	return v != KibanaVersion{}
+}
+
+// AtLeast reports whether the version is major.minor or later. An unknown
+// version is assumed to be recent enough, the request then fails in Kibana
+// if it isn't.
+func (v KibanaVersion) AtLeast(major, minor int) bool {
+	if !v.IsKnown() {
+		return true
+	}
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+func (v KibanaVersion) String() string {
+	if !v.IsKnown() {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+type KibanaStatus struct {
+	Name    string              `json:"name"`
+	Uuid    string              `json:"uuid"`
+	Version KibanaStatusVersion `json:"version"`
+	Status  KibanaStatusDetails `json:"status"`
+}
+
+type KibanaStatusVersion struct {
+	Number        string `json:"number"`
+	BuildHash     string `json:"build_hash"`
+	BuildNumber   int    `json:"build_number"`
+	BuildSnapshot bool   `json:"build_snapshot"`
+}
+
+// KibanaStatusDetails is the status of Kibana and of its plugins. Statuses
+// in the format of Kibana 7, with green, yellow and red states, are converted
+// to the levels of Kibana 8.
+type KibanaStatusDetails struct {
+	Overall KibanaServiceStatus
+	Plugins map[string]KibanaServiceStatus
+}
+
+type KibanaServiceStatus struct {
+	// Level is available, degraded, unavailable or critical.
+	Level   string
+	Summary string
+}
+
+const KibanaStatusAvailable = "available"
+
+var legacyStatusLevels = map[string]string{
+	"green":  KibanaStatusAvailable,
+	"yellow": "degraded",
+	"red":    "unavailable",
+}
+
+type kibanaServiceStatusJson struct {
+	Id      string `json:"id"`
+	Level   string `json:"level"`
+	Summary string `json:"summary"`
+	State   string `json:"state"`
+	Message string `json:"message"`
+	Title   string `json:"title"`
+}
+
+func (s kibanaServiceStatusJson) status() KibanaServiceStatus {
+	if s.Level != "" {
+		return KibanaServiceStatus{Level: s.Level, Summary: s.Summary}
+	}
+	level, ok := legacyStatusLevels[s.State]
+	if !ok {
+		level = s.State
+	}
+	summary := s.Message
+	if summary == "" {
+		summary = s.Title
+	}
+	return KibanaServiceStatus{Level: level, Summary: summary}
+}
+
+func (s *KibanaStatusDetails) UnmarshalJSON(data []byte) error {
+	var details struct {
+		Overall  kibanaServiceStatusJson            `json:"overall"`
+		Plugins  map[string]kibanaServiceStatusJson `json:"plugins"`
+		Statuses []kibanaServiceStatusJson          `json:"statuses"`
+	}
+	if err := json.Unmarshal(data, &details); err != nil {
+		return err
+	}
+	s.Overall = details.Overall.status()
+	s.Plugins = make(map[string]KibanaServiceStatus)
+	for name, plugin := range details.Plugins {
+		s.Plugins[name] = plugin.status()
+	}
+	// Kibana 7 lists the plugins as plugin:name@version, next to the core
+	// services.
+	for _, plugin := range details.Statuses {
+		if !strings.HasPrefix(plugin.Id, "plugin:") {
+			continue
+		}
+		name := strings.TrimPrefix(strings.SplitN(plugin.Id, "@", 2)[0], "plugin:")
+		s.Plugins[name] = plugin.status()
+	}
+	return nil
+}
+
+func (c *KibanaClient) ReadStatus() (KibanaStatus, error) {
+	var status KibanaStatus
+	url := fmt.Sprintf("%s/api/status", c.host)
+	r, statusCode, err := c.api.Get(url, c.requestHeaders())
+	if err != nil {
+		return status, errors.Wrapf(err, "Reading status failed")
+	}
+	// Kibana answers 503 while it is not available yet, with the same body.
+	if statusCode != 200 && statusCode != 503 {
+		return status, fmt.Errorf("Received status %d: %s", statusCode, string(r))
+	}
+	err = json.Unmarshal(r, &status)
+	return status, err
+}
+
+// DetectVersion reads the version of Kibana and keeps it for the endpoints
+// which differ across versions.
+func (c *KibanaClient) DetectVersion() (KibanaVersion, error) {
+	status, err := c.ReadStatus()
+	if err != nil {
+		return KibanaVersion{}, errors.Wrapf(err, "Detecting Kibana version failed")
+	}
+	version, err := ParseKibanaVersion(status.Version.Number)
+	if err != nil {
+		return KibanaVersion{}, err
+	}
+	c.version = version
+	return version, nil
+}
+
+// Version returns the version found by DetectVersion.
+func (c *KibanaClient) Version() KibanaVersion {
+	return c.version
+}
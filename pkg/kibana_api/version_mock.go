@@ -0,0 +1,26 @@
+package kibana
+
+func (c *KibanaMockClient) ReadStatus() (KibanaStatus, error) {
+	return KibanaStatus{
+		Name:    "kibana",
+		Version: KibanaStatusVersion{Number: c.VersionNumber},
+		Status: KibanaStatusDetails{
+			Overall: KibanaServiceStatus{Level: KibanaStatusAvailable, Summary: "All services are available"},
+			Plugins: map[string]KibanaServiceStatus{
+				"alerting": {Level: KibanaStatusAvailable, Summary: "Alerting is available"},
+			},
+		},
+	}, nil
+}
+
+func (c *KibanaMockClient) DetectVersion() (KibanaVersion, error) {
+	if c.VersionNumber == "" {
+		return KibanaVersion{}, nil
+	}
+	return ParseKibanaVersion(c.VersionNumber)
+}
+
+func (c *KibanaMockClient) Version() KibanaVersion {
+	version, _ := c.DetectVersion()
+	return version
+}
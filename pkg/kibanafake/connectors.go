@@ -0,0 +1,131 @@
+package kibanafake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type connector struct {
+	Id               string          `json:"id"`
+	Name             string          `json:"name"`
+	ConnectorTypeId  string          `json:"connector_type_id"`
+	Config           json.RawMessage `json:"config"`
+	IsPreconfigured  bool            `json:"is_preconfigured"`
+	IsDeprecated     bool            `json:"is_deprecated"`
+	IsMissingSecrets bool            `json:"is_missing_secrets"`
+	// secrets are write only, like in Kibana.
+	secrets json.RawMessage
+}
+
+var (
+	createConnectorFields   = []string{"name", "connector_type_id", "config", "secrets"}
+	createConnectorRequired = []string{"name", "connector_type_id"}
+	updateConnectorFields   = []string{"name", "config", "secrets"}
+	updateConnectorRequired = []string{"name"}
+)
+
+// listConnectors serves GET /api/actions/connectors.
+func (s *Server) listConnectors(w http.ResponseWriter, r *request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	connectors := make([]*connector, 0, len(s.connectors[r.spaceId]))
+	for _, connector := range s.connectors[r.spaceId] {
+		connectors = append(connectors, connector)
+	}
+	sort.Slice(connectors, func(i, j int) bool {
+		return connectors[i].Name < connectors[j].Name
+	})
+	writeJson(w, http.StatusOK, connectors)
+}
+
+// handleConnectors serves /api/actions/connector[/{id}].
+func (s *Server) handleConnectors(w http.ResponseWriter, r *request) {
+	connectors := s.connectors[r.spaceId]
+	switch {
+	case len(r.path) == 3 && r.Method == http.MethodPost:
+		s.createConnector(w, r, s.newId())
+		return
+	case len(r.path) == 3:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	case r.Method == http.MethodPost:
+		if _, ok := connectors[r.path[3]]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Saved object [action/%s] conflict", r.path[3]))
+			return
+		}
+		s.createConnector(w, r, r.path[3])
+		return
+	}
+	connector, ok := connectors[r.path[3]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Saved object [action/%s] not found", r.path[3]))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJson(w, http.StatusOK, connector)
+	case http.MethodPut:
+		s.updateConnector(w, r, connector)
+	case http.MethodDelete:
+		delete(connectors, connector.Id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) createConnector(w http.ResponseWriter, r *request, connectorId string) {
+	fields := make(map[string]json.RawMessage)
+	if !decodeBody(w, r, fields, createConnectorFields, createConnectorRequired) {
+		return
+	}
+	created := &connector{Id: connectorId}
+	if !decodeField(w, fields, "connector_type_id", "string", &created.ConnectorTypeId) ||
+		!decodeConnector(w, fields, created) {
+		return
+	}
+	// Kibana only registers the connector types of its plugins, which are
+	// all prefixed with a dot.
+	if !strings.HasPrefix(created.ConnectorTypeId, ".") {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Action type \"%s\" is not registered.", created.ConnectorTypeId))
+		return
+	}
+	if s.connectors[r.spaceId] == nil {
+		s.connectors[r.spaceId] = make(map[string]*connector)
+	}
+	s.connectors[r.spaceId][created.Id] = created
+	writeJson(w, http.StatusOK, created)
+}
+
+func (s *Server) updateConnector(w http.ResponseWriter, r *request, current *connector) {
+	fields := make(map[string]json.RawMessage)
+	if !decodeBody(w, r, fields, updateConnectorFields, updateConnectorRequired) {
+		return
+	}
+	updated := *current
+	updated.Config = nil
+	updated.secrets = nil
+	if !decodeConnector(w, fields, &updated) {
+		return
+	}
+	*current = updated
+	writeJson(w, http.StatusOK, current)
+}
+
+// decodeConnector decodes the fields which can be both created and updated.
+func decodeConnector(w http.ResponseWriter, fields map[string]json.RawMessage, connector *connector) bool {
+	if !decodeField(w, fields, "name", "string", &connector.Name) ||
+		!decodeField(w, fields, "config", "Object", &connector.Config) ||
+		!decodeField(w, fields, "secrets", "Object", &connector.secrets) {
+		return false
+	}
+	if connector.Config == nil {
+		connector.Config = json.RawMessage("{}")
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package kibanafake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+type rule struct {
+	Id              string              `json:"id"`
+	Name            string              `json:"name"`
+	Tags            []string            `json:"tags"`
+	RuleTypeId      string              `json:"rule_type_id"`
+	Consumer        string              `json:"consumer"`
+	Schedule        map[string]string   `json:"schedule"`
+	Throttle        *string             `json:"throttle"`
+	NotifyWhen      *string             `json:"notify_when"`
+	Enabled         bool                `json:"enabled"`
+	MuteAll         bool                `json:"mute_all"`
+	MutedAlertIds   []string            `json:"muted_alert_ids"`
+	Params          json.RawMessage     `json:"params"`
+	Actions         []ruleAction        `json:"actions"`
+	CreatedBy       string              `json:"created_by"`
+	UpdatedBy       string              `json:"updated_by"`
+	CreatedAt       string              `json:"created_at"`
+	UpdatedAt       string              `json:"updated_at"`
+	ExecutionStatus ruleExecutionStatus `json:"execution_status"`
+}
+
+type ruleAction struct {
+	Id              string          `json:"id"`
+	Group           string          `json:"group"`
+	Params          json.RawMessage `json:"params"`
+	Frequency       json.RawMessage `json:"frequency,omitempty"`
+	ConnectorTypeId string          `json:"connector_type_id"`
+}
+
+type ruleExecutionStatus struct {
+	Status            string `json:"status"`
+	LastExecutionDate string `json:"last_execution_date"`
+}
+
+var (
+	createRuleFields   = []string{"name", "tags", "rule_type_id", "consumer", "schedule", "throttle", "notify_when", "enabled", "params", "actions"}
+	createRuleRequired = []string{"name", "rule_type_id", "consumer", "schedule", "params"}
+	updateRuleFields   = []string{"name", "tags", "schedule", "throttle", "notify_when", "params", "actions"}
+	updateRuleRequired = []string{"name", "schedule", "params"}
+	ruleInterval       = regexp.MustCompile(`^[1-9][0-9]*[smhd]$`)
+	ruleNotifyWhen     = map[string]bool{"onActionGroupChange": true, "onActiveAlert": true, "onThrottleInterval": true}
+)
+
+// handleRules serves /api/alerting/rule[/{id}[/_enable|_disable]].
+func (s *Server) handleRules(w http.ResponseWriter, r *request) {
+	rules := s.rules[r.spaceId]
+	switch {
+	case len(r.path) == 3 && r.Method == http.MethodPost:
+		s.createRule(w, r, s.newId())
+		return
+	case len(r.path) == 3:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	case len(r.path) == 4 && r.Method == http.MethodPost:
+		if _, ok := rules[r.path[3]]; ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Saved object [alert/%s] conflict", r.path[3]))
+			return
+		}
+		s.createRule(w, r, r.path[3])
+		return
+	}
+	rule, ok := rules[r.path[3]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Saved object [alert/%s] not found", r.path[3]))
+		return
+	}
+	switch {
+	case len(r.path) == 4 && r.Method == http.MethodGet:
+		writeJson(w, http.StatusOK, rule)
+	case len(r.path) == 4 && r.Method == http.MethodPut:
+		s.updateRule(w, r, rule)
+	case len(r.path) == 4 && r.Method == http.MethodDelete:
+		delete(rules, rule.Id)
+		w.WriteHeader(http.StatusNoContent)
+	case r.path[4] == "_enable" && r.Method == http.MethodPost:
+		rule.Enabled = true
+		w.WriteHeader(http.StatusNoContent)
+	case r.path[4] == "_disable" && r.Method == http.MethodPost:
+		rule.Enabled = false
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) createRule(w http.ResponseWriter, r *request, ruleId string) {
+	fields := make(map[string]json.RawMessage)
+	if !decodeBody(w, r, fields, createRuleFields, createRuleRequired) {
+		return
+	}
+	created := &rule{
+		Id:            ruleId,
+		Tags:          []string{},
+		Enabled:       true,
+		MutedAlertIds: []string{},
+		CreatedBy:     "elastic",
+		UpdatedBy:     "elastic",
+		CreatedAt:     now(),
+		ExecutionStatus: ruleExecutionStatus{
+			Status:            "pending",
+			LastExecutionDate: now(),
+		},
+	}
+	created.UpdatedAt = created.CreatedAt
+	if !decodeField(w, fields, "rule_type_id", "string", &created.RuleTypeId) ||
+		!decodeField(w, fields, "consumer", "string", &created.Consumer) ||
+		!decodeField(w, fields, "enabled", "boolean", &created.Enabled) ||
+		!s.decodeRule(w, r, fields, created) {
+		return
+	}
+	if s.rules[r.spaceId] == nil {
+		s.rules[r.spaceId] = make(map[string]*rule)
+	}
+	s.rules[r.spaceId][created.Id] = created
+	writeJson(w, http.StatusOK, created)
+}
+
+func (s *Server) updateRule(w http.ResponseWriter, r *request, current *rule) {
+	fields := make(map[string]json.RawMessage)
+	if !decodeBody(w, r, fields, updateRuleFields, updateRuleRequired) {
+		return
+	}
+	updated := *current
+	updated.Tags = []string{}
+	updated.Throttle = nil
+	updated.NotifyWhen = nil
+	updated.Actions = nil
+	if !s.decodeRule(w, r, fields, &updated) {
+		return
+	}
+	updated.UpdatedAt = now()
+	*current = updated
+	writeJson(w, http.StatusOK, current)
+}
+
+// decodeRule decodes and validates the fields which can be both created and
+// updated.
+func (s *Server) decodeRule(w http.ResponseWriter, r *request, fields map[string]json.RawMessage, rule *rule) bool {
+	if !decodeField(w, fields, "name", "string", &rule.Name) ||
+		!decodeField(w, fields, "tags", "Array", &rule.Tags) ||
+		!decodeField(w, fields, "schedule", "Object", &rule.Schedule) ||
+		!decodeField(w, fields, "throttle", "string", &rule.Throttle) ||
+		!decodeField(w, fields, "notify_when", "string", &rule.NotifyWhen) ||
+		!decodeField(w, fields, "params", "Object", &rule.Params) ||
+		!decodeField(w, fields, "actions", "Array", &rule.Actions) {
+		return false
+	}
+	if rule.Tags == nil {
+		rule.Tags = []string{}
+	}
+	if rule.Actions == nil {
+		rule.Actions = []ruleAction{}
+	}
+	if !ruleInterval.MatchString(rule.Schedule["interval"]) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("[request body.schedule.interval]: string is not a valid duration: %s", rule.Schedule["interval"]))
+		return false
+	}
+	if rule.Throttle != nil && !ruleInterval.MatchString(*rule.Throttle) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("[request body.throttle]: string is not a valid duration: %s", *rule.Throttle))
+		return false
+	}
+	if rule.NotifyWhen != nil && !ruleNotifyWhen[*rule.NotifyWhen] {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("[request body.notify_when]: string is not a valid RuleNotifyWhenType: %s", *rule.NotifyWhen))
+		return false
+	}
+	for i := range rule.Actions {
+		action := &rule.Actions[i]
+		connector, ok := s.connectors[r.spaceId][action.Id]
+		if !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Failed to validate actions due to the following error: Saved object [action/%s] not found", action.Id))
+			return false
+		}
+		action.ConnectorTypeId = connector.ConnectorTypeId
+		if action.Group == "" {
+			action.Group = "default"
+		}
+		if action.Params == nil {
+			action.Params = json.RawMessage("{}")
+		}
+	}
+	return true
+}
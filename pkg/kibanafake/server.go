@@ -0,0 +1,226 @@
+// Package kibanafake provides an in-memory Kibana HTTP server for hermetic
+// tests of the Kibana client. It implements the status, alerting rule,
+// connector and space endpoints, validating the requests and answering
+// errors like Kibana does.
+package kibanafake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is an in-memory Kibana. Objects live in the space they are created
+// in, addressed with the /s/{space_id} path prefix.
+type Server struct {
+	*httptest.Server
+	// Version is the Kibana version reported by /api/status.
+	Version string
+
+	mutex      sync.Mutex
+	lastId     int
+	spaces     map[string]*space
+	rules      map[string]map[string]*rule
+	connectors map[string]map[string]*connector
+}
+
+// NewServer starts a fake Kibana 8.6.0 with the default space. The server is
+// stopped with Close.
+func NewServer() *Server {
+	s := &Server{
+		Version:    "8.6.0",
+		spaces:     map[string]*space{defaultSpaceId: defaultSpace()},
+		rules:      make(map[string]map[string]*rule),
+		connectors: make(map[string]map[string]*connector),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// kibanaError is the body of Kibana error responses.
+type kibanaError struct {
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+}
+
+// request is a request routed to an API of a space.
+type request struct {
+	*http.Request
+	spaceId string
+	// path is the path of the request without the space prefix, split on /.
+	path []string
+}
+
+var spacePrefix = regexp.MustCompile(`^/s/([^/]+)(/.*)$`)
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if r.Method != http.MethodGet && r.Header.Get("kbn-xsrf") == "" {
+		writeError(w, http.StatusBadRequest, "Request must contain a kbn-xsrf header.")
+		return
+	}
+	req := &request{Request: r, spaceId: defaultSpaceId}
+	path := r.URL.Path
+	if match := spacePrefix.FindStringSubmatch(path); match != nil {
+		req.spaceId, path = match[1], match[2]
+	}
+	req.path = strings.Split(strings.Trim(path, "/"), "/")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.spaces[req.spaceId]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	switch {
+	case req.matches("api", "status"):
+		s.handleStatus(w, req)
+	case req.matches("api", "alerting", "rule") || req.matches("api", "alerting", "rule", "*") || req.matches("api", "alerting", "rule", "*", "*"):
+		s.handleRules(w, req)
+	case req.matches("api", "actions", "connectors"):
+		s.listConnectors(w, req)
+	case req.matches("api", "actions", "connector") || req.matches("api", "actions", "connector", "*"):
+		s.handleConnectors(w, req)
+	case req.matches("api", "spaces", "space") || req.matches("api", "spaces", "space", "*"):
+		s.handleSpaces(w, req)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// matches reports whether the path of the request is made of the given
+// segments, * matching any segment.
+func (r *request) matches(segments ...string) bool {
+	if len(r.path) != len(segments) {
+		return false
+	}
+	for i, segment := range segments {
+		if segment != "*" && segment != r.path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"name": "kibanafake",
+		"uuid": "00000000-0000-4000-8000-000000000000",
+		"version": map[string]interface{}{
+			"number":         s.Version,
+			"build_hash":     "kibanafake",
+			"build_number":   1,
+			"build_snapshot": false,
+		},
+		"status": map[string]interface{}{
+			"overall": map[string]string{"level": "available", "summary": "All services are available"},
+			"plugins": map[string]interface{}{
+				"alerting": map[string]string{"level": "available", "summary": "Alerting is available"},
+				"actions":  map[string]string{"level": "available", "summary": "Actions are available"},
+				"spaces":   map[string]string{"level": "available", "summary": "Spaces are available"},
+			},
+		},
+	})
+}
+
+// newId returns a deterministic ID, formatted as a UUID. The caller holds the
+// mutex.
+func (s *Server) newId() string {
+	s.lastId++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.lastId)
+}
+
+// decodeBody decodes the JSON body of the request into fields, rejecting the
+// keys which aren't allowed and the missing required ones like Kibana does.
+func decodeBody(w http.ResponseWriter, r *request, fields map[string]json.RawMessage, allowed []string, required []string) bool {
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request payload JSON format: %s", err))
+		return false
+	}
+	allowedKeys := make(map[string]bool, len(allowed))
+	for _, key := range allowed {
+		allowedKeys[key] = true
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !allowedKeys[key] {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("[request body.%s]: definition for this key is missing", key))
+			return false
+		}
+	}
+	for _, key := range required {
+		if value, ok := fields[key]; !ok || string(value) == "null" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("[request body.%s]: expected value of type [string] but got [undefined]", key))
+			return false
+		}
+	}
+	return true
+}
+
+// decodeField decodes the field of a request body, answering 400 when it has
+// the wrong type.
+func decodeField(w http.ResponseWriter, fields map[string]json.RawMessage, key, expected string, value interface{}) bool {
+	raw, ok := fields[key]
+	if !ok {
+		return true
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("[request body.%s]: expected value of type [%s] but got [%s]", key, expected, jsonType(raw)))
+		return false
+	}
+	return true
+}
+
+func jsonType(raw json.RawMessage) string {
+	var value interface{}
+	json.Unmarshal(raw, &value)
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "Array"
+	case map[string]interface{}:
+		return "Object"
+	}
+	return "null"
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJson(w, statusCode, kibanaError{
+		StatusCode: statusCode,
+		Error:      http.StatusText(statusCode),
+		Message:    message,
+	})
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,176 @@
+package kibanafake_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	mykibana "github.com/qonto/terraform-provider-kibana/pkg/kibana_api"
+	"github.com/qonto/terraform-provider-kibana/pkg/kibanafake"
+)
+
+// do sends a request to the fake and returns the status code and the decoded
+// body of the response.
+func do(t *testing.T, fake *kibanafake.Server, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, fake.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Basic auth")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("kbn-xsrf", "true")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string]interface{})
+	if len(bytes.TrimSpace(raw)) > 0 && raw[0] == '{' {
+		if err := json.Unmarshal(raw, &result); err != nil {
+			t.Fatalf("%s %s: %s", method, path, err)
+		}
+	}
+	return resp.StatusCode, result
+}
+
+func TestKibanaClientAlertRules(t *testing.T) {
+	fake := kibanafake.NewServer()
+	defer fake.Close()
+	status, connector := do(t, fake, "POST", "/api/actions/connector", `{"name":"Logs","connector_type_id":".server-log"}`)
+	if status != 200 {
+		t.Fatalf("Creating connector: %d %v", status, connector)
+	}
+
+	client := mykibana.CreateNewKibanaClient()
+	client.SetupClient(fake.URL, "auth")
+	version, err := client.DetectVersion()
+	if err != nil || version.String() != "8.6.0" {
+		t.Fatalf("DetectVersion: %v %v", version, err)
+	}
+	alert := mykibana.Alert{
+		Name:       "Test alert",
+		Tags:       []string{"ok"},
+		RuleTypeId: ".index-threshold",
+		Consumer:   "alerts",
+		Schedule:   map[string]string{"interval": "1m"},
+		NotifyWhen: "onActiveAlert",
+		Params:     json.RawMessage(`{"index":["logs-*"]}`),
+		Actions: []mykibana.Action{
+			{Id: connector["id"].(string), Group: "threshold met", Params: json.RawMessage(`{"message":"alert"}`)},
+		},
+	}
+	alertId, err := client.CreateAlertRule(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := client.ReadAlertRule(alertId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Name != alert.Name || !read.Enabled || read.Schedule["interval"] != "1m" || len(read.Actions) != 1 || string(read.Params) != `{"index":["logs-*"]}` {
+		t.Errorf("Unexpected rule %+v", read)
+	}
+
+	if err := client.DisableRule(alertId); err != nil {
+		t.Fatal(err)
+	}
+	alert.Name = "Renamed alert"
+	alert.RuleTypeId, alert.Consumer = "", ""
+	if err := client.UpdateAlertRule(alertId, alert); err != nil {
+		t.Fatal(err)
+	}
+	read, err = client.ReadAlertRule(alertId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Name != "Renamed alert" || read.Enabled || read.RuleTypeId != ".index-threshold" {
+		t.Errorf("Unexpected rule %+v", read)
+	}
+
+	if err := client.DeleteAlertRule(alertId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ReadAlertRule(alertId); !errors.Is(err, mykibana.ErrNotFound) {
+		t.Errorf("Reading a deleted rule should fail with ErrNotFound, got %v", err)
+	}
+}
+
+func TestServerValidation(t *testing.T) {
+	fake := kibanafake.NewServer()
+	defer fake.Close()
+	rule := `{"name":"Test","rule_type_id":".index-threshold","consumer":"alerts","schedule":{"interval":"1m"},"params":{}}`
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+		message            string
+	}{
+		{"POST", "/api/alerting/rule", `{"rule_type_id":".index-threshold"}`, 400, "[request body.name]: expected value of type [string] but got [undefined]"},
+		{"POST", "/api/alerting/rule", `{"name":1,"rule_type_id":".index-threshold","consumer":"alerts","schedule":{"interval":"1m"},"params":{}}`, 400, "[request body.name]: expected value of type [string] but got [number]"},
+		{"POST", "/api/alerting/rule", `{"name":"Test","rule_type_id":".index-threshold","consumer":"alerts","schedule":{"interval":"1 minute"},"params":{}}`, 400, "[request body.schedule.interval]: string is not a valid duration: 1 minute"},
+		{"POST", "/api/alerting/rule", `{"name":"Test","rule_type_id":".index-threshold","consumer":"alerts","schedule":{"interval":"1m"},"params":{},"actions":[{"id":"missing","group":"default","params":{}}]}`, 400, "Failed to validate actions due to the following error: Saved object [action/missing] not found"},
+		{"POST", "/api/alerting/rule/fixed-id", rule, 200, ""},
+		{"POST", "/api/alerting/rule/fixed-id", rule, 409, "Saved object [alert/fixed-id] conflict"},
+		{"PUT", "/api/alerting/rule/fixed-id", rule, 400, "[request body.consumer]: definition for this key is missing"},
+		{"GET", "/api/alerting/rule/unknown", "", 404, "Saved object [alert/unknown] not found"},
+		{"GET", "/s/unknown/api/alerting/rule/fixed-id", "", 404, "Not Found"},
+		{"POST", "/api/actions/connector", `{"name":"Logs","connector_type_id":"server-log"}`, 400, "Action type \"server-log\" is not registered."},
+		{"POST", "/api/spaces/space", `{"id":"Team A","name":"Team A"}`, 400, "[request body.id]: lower case, a-z, 0-9, \"_\", and \"-\" are allowed"},
+		{"DELETE", "/api/spaces/space/default", "", 400, "The default space cannot be deleted because it is reserved."},
+	} {
+		status, body := do(t, fake, tc.method, tc.path, tc.body)
+		if status != tc.status || (tc.message != "" && body["message"] != tc.message) {
+			t.Errorf("%s %s: got %d %v, expected %d %q", tc.method, tc.path, status, body, tc.status, tc.message)
+		}
+		if status >= 400 && (body["statusCode"] != float64(status) || body["error"] != http.StatusText(status)) {
+			t.Errorf("%s %s: unexpected error body %v", tc.method, tc.path, body)
+		}
+	}
+
+	req, _ := http.NewRequest("POST", fake.URL+"/api/alerting/rule", strings.NewReader(rule))
+	req.Header.Set("Authorization", "Basic auth")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Errorf("Requests without kbn-xsrf should be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerSpaces(t *testing.T) {
+	fake := kibanafake.NewServer()
+	defer fake.Close()
+	if status, body := do(t, fake, "POST", "/api/spaces/space", `{"id":"team-a","name":"Team A"}`); status != 200 {
+		t.Fatalf("Creating space: %d %v", status, body)
+	}
+	if status, body := do(t, fake, "POST", "/api/spaces/space", `{"id":"team-a","name":"Team A"}`); status != 409 {
+		t.Errorf("Creating a space twice should conflict, got %d %v", status, body)
+	}
+	status, connector := do(t, fake, "POST", "/s/team-a/api/actions/connector", `{"name":"Logs","connector_type_id":".server-log","secrets":{"token":"secret"}}`)
+	if status != 200 || connector["secrets"] != nil {
+		t.Fatalf("Creating connector: %d %v", status, connector)
+	}
+	path := "/api/actions/connector/" + connector["id"].(string)
+	if status, _ := do(t, fake, "GET", "/s/team-a"+path, ""); status != 200 {
+		t.Errorf("Connector should exist in its space, got %d", status)
+	}
+	if status, _ := do(t, fake, "GET", path, ""); status != 404 {
+		t.Errorf("Connector should not exist in the default space, got %d", status)
+	}
+	if status, _ := do(t, fake, "DELETE", "/api/spaces/space/team-a", ""); status != 204 {
+		t.Errorf("Deleting space: %d", status)
+	}
+	if status, _ := do(t, fake, "GET", "/s/team-a"+path, ""); status != 404 {
+		t.Errorf("Space should be deleted with its objects, got %d", status)
+	}
+}
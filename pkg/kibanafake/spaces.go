@@ -0,0 +1,139 @@
+package kibanafake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+)
+
+const defaultSpaceId = "default"
+
+type space struct {
+	Id               string   `json:"id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	Color            string   `json:"color,omitempty"`
+	Initials         string   `json:"initials,omitempty"`
+	ImageUrl         string   `json:"imageUrl,omitempty"`
+	DisabledFeatures []string `json:"disabledFeatures"`
+	Reserved         bool     `json:"_reserved,omitempty"`
+}
+
+var (
+	spaceFields   = []string{"id", "name", "description", "color", "initials", "imageUrl", "disabledFeatures"}
+	spaceRequired = []string{"id", "name"}
+	spaceIdFormat = regexp.MustCompile(`^[a-z0-9_\-]+$`)
+)
+
+func defaultSpace() *space {
+	return &space{
+		Id:               defaultSpaceId,
+		Name:             "Default",
+		Description:      "This is your default space!",
+		Color:            "#00bfb3",
+		DisabledFeatures: []string{},
+		Reserved:         true,
+	}
+}
+
+// handleSpaces serves /api/spaces/space[/{id}]. The spaces are global, they
+// can't be managed from within another space.
+func (s *Server) handleSpaces(w http.ResponseWriter, r *request) {
+	if r.spaceId != defaultSpaceId {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if len(r.path) == 3 {
+		switch r.Method {
+		case http.MethodGet:
+			spaces := make([]*space, 0, len(s.spaces))
+			for _, space := range s.spaces {
+				spaces = append(spaces, space)
+			}
+			sort.Slice(spaces, func(i, j int) bool {
+				return spaces[i].Id < spaces[j].Id
+			})
+			writeJson(w, http.StatusOK, spaces)
+		case http.MethodPost:
+			s.createSpace(w, r)
+		default:
+			writeError(w, http.StatusNotFound, "Not Found")
+		}
+		return
+	}
+	space, ok := s.spaces[r.path[3]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJson(w, http.StatusOK, space)
+	case http.MethodPut:
+		s.updateSpace(w, r, space)
+	case http.MethodDelete:
+		if space.Reserved {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("The %s space cannot be deleted because it is reserved.", space.Id))
+			return
+		}
+		delete(s.spaces, space.Id)
+		delete(s.rules, space.Id)
+		delete(s.connectors, space.Id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) createSpace(w http.ResponseWriter, r *request) {
+	space := &space{}
+	if !decodeSpace(w, r, space) {
+		return
+	}
+	if _, ok := s.spaces[space.Id]; ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("A space with the identifier %s already exists.", space.Id))
+		return
+	}
+	s.spaces[space.Id] = space
+	writeJson(w, http.StatusOK, space)
+}
+
+func (s *Server) updateSpace(w http.ResponseWriter, r *request, current *space) {
+	updated := &space{}
+	if !decodeSpace(w, r, updated) {
+		return
+	}
+	if updated.Id != current.Id {
+		writeError(w, http.StatusBadRequest, "Space ID in request body does not match the space ID in the URL")
+		return
+	}
+	updated.Reserved = current.Reserved
+	*current = *updated
+	writeJson(w, http.StatusOK, current)
+}
+
+func decodeSpace(w http.ResponseWriter, r *request, space *space) bool {
+	fields := make(map[string]json.RawMessage)
+	if !decodeBody(w, r, fields, spaceFields, spaceRequired) {
+		return false
+	}
+	if !decodeField(w, fields, "id", "string", &space.Id) ||
+		!decodeField(w, fields, "name", "string", &space.Name) ||
+		!decodeField(w, fields, "description", "string", &space.Description) ||
+		!decodeField(w, fields, "color", "string", &space.Color) ||
+		!decodeField(w, fields, "initials", "string", &space.Initials) ||
+		!decodeField(w, fields, "imageUrl", "string", &space.ImageUrl) ||
+		!decodeField(w, fields, "disabledFeatures", "Array", &space.DisabledFeatures) {
+		return false
+	}
+	if !spaceIdFormat.MatchString(space.Id) {
+		writeError(w, http.StatusBadRequest, "[request body.id]: lower case, a-z, 0-9, \"_\", and \"-\" are allowed")
+		return false
+	}
+	if space.DisabledFeatures == nil {
+		space.DisabledFeatures = []string{}
+	}
+	return true
+}